@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// behaviour describes how a faked or mocked call behaves beyond returning a
+// configured result and error: delaying, blocking, panicking or computing the
+// result from the arguments of the call.
+type behaviour struct {
+	// delay is a duration to wait before returning
+	delay time.Duration
+
+	// until is a channel to be waited on before returning; nil if the call
+	// does not block
+	until <-chan struct{}
+
+	// panics is true if the call panics with panicValue
+	panics     bool
+	panicValue any
+
+	// fn is a func(A) (R, error) computing the result and error of a call;
+	// it is held as an any since the behaviour is not generic
+	fn any
+}
+
+// blockUntil returns a channel to be waited on for a context, chan struct{} or
+// <-chan struct{}.  Any other value causes a panic with ErrInvalidArgument,
+// identifying the function to which the value was passed.
+//
+// A nil channel, or a context that can never be done (e.g. context.Background(),
+// with a nil Done channel), also causes a panic with ErrInvalidArgument, since
+// a call would otherwise either not block at all or block forever.
+func blockUntil(fn string, v any) <-chan struct{} {
+	var ch <-chan struct{}
+	switch v := v.(type) {
+	case context.Context:
+		if ch = v.Done(); ch == nil {
+			panic(fmt.Errorf("%s: %w: context can never be done", fn, ErrInvalidArgument))
+		}
+	case chan struct{}:
+		ch = v
+	case <-chan struct{}:
+		ch = v
+	default:
+		panic(fmt.Errorf("%s: %w: %T: must be a context.Context, chan struct{} or <-chan struct{}", fn, ErrInvalidArgument, v))
+	}
+
+	if ch == nil {
+		panic(fmt.Errorf("%s: %w: nil channel", fn, ErrInvalidArgument))
+	}
+	return ch
+}
+
+// applyBehaviour applies a behaviour to a call with specified arguments, returning
+// the result and error of the call.  A function computing the result is called
+// only if the call met expectations; otherwise the specified result and error
+// are returned.
+func applyBehaviour[A, R any](b *behaviour, met bool, args A, result R, err error) (R, error) {
+	if b.delay > 0 {
+		time.Sleep(b.delay)
+	}
+
+	if b.until != nil {
+		<-b.until
+	}
+
+	if b.panics {
+		panic(b.panicValue)
+	}
+
+	if fn, ok := b.fn.(func(A) (R, error)); ok && met {
+		return fn(args)
+	}
+
+	return result, err
+}
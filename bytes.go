@@ -15,13 +15,82 @@ import "github.com/blugnu/test/matchers/bytes"
 // portion of the expected and actual byte slices to highlight the first such
 // difference.  See the example for a demonstration.
 //
+// Alternatively, the opt.HexDump(true) option may be used to present a
+// hexdump of every region of differences (up to a limit), with offsets,
+// hex columns and an ASCII gutter.  This is useful when the first difference
+// is not the most significant, e.g. when testing binary protocol encoders.
+//
 // # Supported Options
 //
-// This is a highly specialised matcher; the only supported option is a
-// custom failure report function:
+// This is a highly specialised matcher; the supported options are:
+//
+//	opt.HexDump(bool)        // present differences in a hexdump format
+//	                         // (default is false)
+//
+//	opt.MaxDiffRegions(int)  // the maximum number of regions of differences
+//	                         // presented in a hexdump (default 3; zero for
+//	                         // no limit)
 //
 //	opt.FailureReport(func)  // a function returning a custom failure report
 //	                         // in the event that the slices are not equal
 func EqualBytes[T ~byte](want []T) *bytes.EqualMatcher[T] {
 	return &bytes.EqualMatcher[T]{Expected: want}
 }
+
+// ContainBytes returns a matcher that checks if a byte slice contains an
+// expected sequence of bytes.
+//
+// The type T must be byte or a type that is assignable to byte.
+//
+// If the test fails, the failure report presents the expected bytes and a
+// hexdump of the byte slice being tested.
+//
+// If no expected bytes are specified the test fails as invalid.
+//
+// # Supported Options
+//
+//	opt.FailureReport(func)  // a function returning a custom failure report
+//	                         // in the event that the test fails
+func ContainBytes[T ~byte](want []T) *bytes.ContainsMatcher[T] {
+	return &bytes.ContainsMatcher[T]{Expected: want}
+}
+
+// HaveBytePrefix returns a matcher that checks if a byte slice begins with an
+// expected sequence of bytes.
+//
+// The type T must be byte or a type that is assignable to byte.
+//
+// If the test fails, the failure report presents a hexdump of the
+// differences between the expected prefix and the corresponding bytes
+// of the byte slice being tested.
+//
+// # Supported Options
+//
+//	opt.MaxDiffRegions(int)  // the maximum number of regions of differences
+//	                         // presented in the failure report (default 3)
+//
+//	opt.FailureReport(func)  // a function returning a custom failure report
+//	                         // in the event that the test fails
+func HaveBytePrefix[T ~byte](want []T) *bytes.PrefixMatcher[T] {
+	return &bytes.PrefixMatcher[T]{Expected: want}
+}
+
+// HaveByteSuffix returns a matcher that checks if a byte slice ends with an
+// expected sequence of bytes.
+//
+// The type T must be byte or a type that is assignable to byte.
+//
+// If the test fails, the failure report presents a hexdump of the
+// differences between the expected suffix and the corresponding bytes
+// of the byte slice being tested.
+//
+// # Supported Options
+//
+//	opt.MaxDiffRegions(int)  // the maximum number of regions of differences
+//	                         // presented in the failure report (default 3)
+//
+//	opt.FailureReport(func)  // a function returning a custom failure report
+//	                         // in the event that the test fails
+func HaveByteSuffix[T ~byte](want []T) *bytes.SuffixMatcher[T] {
+	return &bytes.SuffixMatcher[T]{Expected: want}
+}
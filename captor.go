@@ -0,0 +1,102 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Captor captures the arguments of calls to a mock function, for arguments
+// that cannot be known in advance (e.g. generated ids or timestamps) to be
+// tested once the code under test has been called.
+//
+// A Captor is attached to an expected call using Capture(), capturing the
+// arguments of calls matched with that expected call:
+//
+//	var c Captor[Event]
+//	bus.publish.ExpectCall().WithArgsMatching(AnyArgs[Event]()).Capture(&c)
+//
+//	// .. call the code under test
+//
+//	Expect(c.Last().Payload).To(Equal(`{"id":42}`))
+//
+// A Captor may also be attached to a mock function using the Capture() method
+// of the mock function, capturing the arguments of all calls to the function,
+// including calls made to obtain mapped results using ResultFor().  This is the
+// only way to capture arguments in mapped results mode: the FakeResult returned
+// by WhenCalledWith() (or WhenCalledWithAnything()) does not support Capture().
+//
+// Only arguments actually recorded are captured; a call recorded without any
+// arguments does not capture anything.
+//
+// A Captor is safe for concurrent use; the zero value is ready to use.
+type Captor[A any] struct {
+	mu   sync.Mutex
+	args []A
+}
+
+// capture adds the arguments of a call to those captured.  The receiver may
+// be nil (no captor attached), in which case nothing is captured.
+func (c *Captor[A]) capture(args *A) {
+	if c == nil || args == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.args = append(c.args, *args)
+}
+
+// Values returns a copy of the arguments captured, in the order in which the
+// calls were made.
+func (c *Captor[A]) Values() []A {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]A, len(c.args))
+	copy(result, c.args)
+	return result
+}
+
+// Len returns the number of calls for which arguments have been captured.
+func (c *Captor[A]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.args)
+}
+
+// First returns the arguments of the first call captured.  If no arguments
+// have been captured, First panics with ErrNoCapturedArgs.
+func (c *Captor[A]) First() A {
+	return c.at("First", 0)
+}
+
+// Last returns the arguments of the most recent call captured.  If no arguments
+// have been captured, Last panics with ErrNoCapturedArgs.
+func (c *Captor[A]) Last() A {
+	return c.at("Last", -1)
+}
+
+// at returns the arguments captured at a specified index; a negative index is
+// relative to the end of the captured arguments (i.e. -1 is the last)
+func (c *Captor[A]) at(fn string, idx int) A {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.args) == 0 {
+		panic(fmt.Errorf("Captor.%s: %w", fn, ErrNoCapturedArgs))
+	}
+	if idx < 0 {
+		idx += len(c.args)
+	}
+	return c.args[idx]
+}
+
+// Reset removes all captured arguments.
+func (c *Captor[A]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.args = nil
+}
@@ -0,0 +1,184 @@
+package test_test
+
+import (
+	"testing"
+
+	. "github.com/blugnu/test"
+)
+
+func TestCaptor(t *testing.T) {
+	With(t)
+
+	type event struct {
+		ID      int
+		Payload string
+	}
+
+	Run(Test("expected call", func() {
+		// ARRANGE
+		var c Captor[event]
+		mock := &MockFn[event, any]{}
+		mock.ExpectCall().WithArgsMatching(AnyArgs[event]()).Times(2).Capture(&c)
+		mock.ExpectCall().WithArgs(event{ID: 3})
+
+		// ACT
+		_, _ = mock.RecordCall(event{ID: 1, Payload: "first"})
+		_, _ = mock.RecordCall(event{ID: 2, Payload: "second"})
+		_, _ = mock.RecordCall(event{ID: 3})
+
+		// ASSERT
+		Expect(mock.ExpectationsWereMet()).IsNil()
+		Expect(c.Len()).To(Equal(2))
+		Expect(c.First().Payload).To(Equal("first"))
+		Expect(c.Last().Payload).To(Equal("second"))
+		Expect(c.Values()).To(EqualSlice([]event{{1, "first"}, {2, "second"}}))
+	}))
+
+	Run(Test("expected call with unexpected args", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.ExpectCall().WithArgs(1).Capture(&c)
+
+		// ACT
+		_, err := mock.RecordCall(2)
+
+		// ASSERT
+		Expect(err).Is(ErrUnexpectedArgs)
+		Expect(c.Last()).To(Equal(2))
+	}))
+
+	Run(Test("call without args", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.ExpectCall().Capture(&c)
+
+		// ACT
+		_, _ = mock.RecordCall()
+
+		// ASSERT
+		Expect(c.Len()).To(Equal(0))
+	}))
+
+	Run(Test("mock function", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.Capture(&c)
+		mock.ExpectCall().WithArgs(1)
+
+		// ACT
+		_, _ = mock.RecordCall(1)
+		_, _ = mock.RecordCall(2)
+
+		// ASSERT
+		Expect(c.Values()).To(EqualSlice([]int{1, 2}))
+	}))
+
+	Run(Test("mock function with mapped results", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, string]{}
+		mock.Capture(&c)
+		mock.WhenCalledWith(1).Returns("one")
+
+		// ACT
+		_ = mock.ResultFor(1)
+		_ = mock.ResultFor(1)
+
+		// ASSERT
+		Expect(c.Values()).To(EqualSlice([]int{1, 1}))
+	}))
+
+	Run(Test("mock function with default mapped result", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, string]{}
+		mock.Capture(&c)
+		mock.WhenCalledWith(1).Returns("one")
+		mock.WhenCalledWithAnything().Returns("other")
+
+		// ACT
+		_ = mock.ResultFor(2)
+		_ = mock.ResultFor(1)
+		_ = mock.ResultFor(3)
+
+		// ASSERT
+		Expect(c.Values()).To(EqualSlice([]int{2, 1, 3}))
+	}))
+
+	Run(Test("mock function reset", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.Capture(&c)
+
+		// ACT
+		mock.Reset()
+		mock.ExpectCall().WithArgs(1)
+		_, _ = mock.RecordCall(1)
+
+		// ASSERT
+		Expect(c.Len()).To(Equal(0))
+	}))
+
+	Run(Test("reset", func() {
+		// ARRANGE
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.ExpectCall().WithArgs(1).Capture(&c)
+		_, _ = mock.RecordCall(1)
+
+		// ACT
+		c.Reset()
+
+		// ASSERT
+		Expect(c.Len()).To(Equal(0))
+	}))
+
+	Run(Test("first when nothing captured", func() {
+		defer Expect(Panic(ErrNoCapturedArgs)).DidOccur()
+
+		var c Captor[int]
+		c.First()
+	}))
+
+	Run(Test("last when nothing captured", func() {
+		defer Expect(Panic(ErrNoCapturedArgs)).DidOccur()
+
+		var c Captor[int]
+		c.Last()
+	}))
+
+	Run(Test("nil captor for expected call", func() {
+		defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+		mock := &MockFn[int, any]{}
+		mock.ExpectCall().Capture(nil)
+	}))
+
+	Run(Test("captor already attached to expected call", func() {
+		defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.ExpectCall().Capture(&c).Capture(&c)
+	}))
+
+	Run(Test("nil captor for mock function", func() {
+		defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+		mock := &MockFn[int, any]{}
+		mock.Capture(nil)
+	}))
+
+	Run(Test("captor already attached to mock function", func() {
+		defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+		var c Captor[int]
+		mock := &MockFn[int, any]{}
+		mock.Capture(&c)
+		mock.Capture(&c)
+	}))
+}
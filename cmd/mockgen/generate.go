@@ -0,0 +1,666 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInterfaceNotFound = errors.New("interface not found")
+	ErrInvalidFlags      = errors.New("invalid flags")
+	ErrNoSource          = errors.New("no source files")
+	ErrNotAnInterface    = errors.New("not an interface")
+	ErrUnsupported       = errors.New("unsupported")
+)
+
+// testImportPath is the import path of the blugnu/test package, providing
+// the MockFn type on which generated mocks are built
+const testImportPath = "github.com/blugnu/test"
+
+// config holds the configuration of a mock to be generated
+type config struct {
+	Interface string // name of the interface to be mocked
+	Source    string // directory of the package declaring the interface
+	Mock      string // name of the mock type
+	Package   string // name of the package of the generated mock
+	Import    string // import path of the package declaring the interface
+	Exclude   string // absolute path of a file in Source to be ignored
+}
+
+// method is a method of the interface being mocked, together with the file in
+// which it is declared (required to resolve the imports of any types it uses)
+type method struct {
+	name string
+	fn   *ast.FuncType
+	file *ast.File
+}
+
+// value is an argument or result value of a method
+type value struct {
+	name  string // name of the argument in the method signature
+	field string // name of the field holding the value in a tuple struct
+	typ   string // type of the value as declared in the method signature
+	elem  string // type of the value as held in a tuple struct
+}
+
+// generator holds the state of a mock being generated
+type generator struct {
+	cfg     config
+	fset    *token.FileSet
+	srcPkg  string
+	files   []*ast.File
+	imports map[string]string // import path -> name (empty if not aliased)
+	qualify bool              // true if types of the source package must be qualified
+}
+
+// generate generates the source of a mock for the configured interface.
+func generate(cfg config) ([]byte, error) {
+	g := &generator{
+		cfg:     cfg,
+		fset:    token.NewFileSet(),
+		imports: map[string]string{"errors": "", "fmt": "", testImportPath: ""},
+	}
+
+	if err := g.parse(); err != nil {
+		return nil, err
+	}
+
+	if g.cfg.Mock == "" {
+		g.cfg.Mock = "mock" + cfg.Interface
+	}
+	if g.cfg.Package == "" {
+		g.cfg.Package = g.srcPkg
+	}
+	if g.cfg.Package != g.srcPkg {
+		if g.cfg.Import == "" {
+			return nil, fmt.Errorf("%w: -import is required to generate a mock in a different package", ErrInvalidFlags)
+		}
+		g.qualify = true
+		g.addImport(g.cfg.Import, g.srcPkg)
+	}
+
+	methods, err := g.methods(cfg.Interface, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("%w: %s: interface has no methods", ErrUnsupported, cfg.Interface)
+	}
+
+	verify, err := helperName(cfg.Interface, "ExpectationsWereMet", methods)
+	if err != nil {
+		return nil, err
+	}
+	reset, err := helperName(cfg.Interface, "Reset", methods)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &bytes.Buffer{}
+	g.writeMock(body, methods, verify, reset)
+
+	src := &bytes.Buffer{}
+	fmt.Fprintf(src, "// Code generated by mockgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(src, "package %s\n\n", g.cfg.Package)
+	g.writeImports(src)
+	src.Write(body.Bytes())
+
+	result, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated source: %w", err)
+	}
+	return result, nil
+}
+
+// parse parses the (non-test) source files of the source package
+func (g *generator) parse() error {
+	dir := g.cfg.Source
+	if dir == "" {
+		dir = "."
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		filename := filepath.Join(dir, name)
+		if abs, err := filepath.Abs(filename); err == nil && abs == g.cfg.Exclude {
+			continue
+		}
+
+		file, err := parser.ParseFile(g.fset, filename, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+
+		if g.srcPkg == "" {
+			g.srcPkg = file.Name.Name
+		}
+		if file.Name.Name == g.srcPkg {
+			g.files = append(g.files, file)
+		}
+	}
+
+	if len(g.files) == 0 {
+		return fmt.Errorf("%w: %s", ErrNoSource, dir)
+	}
+	return nil
+}
+
+// lookup returns the declaration of a named type in the source package, and
+// the file in which it is declared
+func (g *generator) lookup(name string) (*ast.TypeSpec, *ast.File) {
+	for _, file := range g.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts := spec.(*ast.TypeSpec); ts.Name.Name == name {
+					return ts, file
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// methods returns the methods of a named interface in the source package,
+// including the methods of any interfaces it embeds (which must also be
+// declared in the source package)
+func (g *generator) methods(name string, seen map[string]bool) ([]method, error) {
+	ts, file := g.lookup(name)
+	if ts == nil {
+		return nil, fmt.Errorf("%w: %s", ErrInterfaceNotFound, name)
+	}
+
+	iface, ok := ts.Type.(*ast.InterfaceType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotAnInterface, name)
+	}
+	if ts.TypeParams != nil {
+		return nil, fmt.Errorf("%w: %s: generic interfaces cannot be mocked", ErrUnsupported, name)
+	}
+
+	result := []method{}
+	for _, field := range iface.Methods.List {
+		switch typ := field.Type.(type) {
+		case *ast.FuncType:
+			for _, id := range field.Names {
+				if !seen[id.Name] {
+					seen[id.Name] = true
+					result = append(result, method{name: id.Name, fn: typ, file: file})
+				}
+			}
+
+		case *ast.Ident:
+			embedded, err := g.methods(typ.Name, seen)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, embedded...)
+
+		default:
+			return nil, fmt.Errorf("%w: %s: embedded %s must be an interface declared in the same package",
+				ErrUnsupported, name, g.print(typ),
+			)
+		}
+	}
+	return result, nil
+}
+
+// addImport adds an import required by the generated mock
+func (g *generator) addImport(importPath, name string) {
+	if name == packageName(importPath) {
+		name = ""
+	}
+	g.imports[importPath] = name
+}
+
+// packageName returns the presumed name of the package with a given import
+// path; that is, the last element of the path, ignoring any major version
+// suffix.
+func packageName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+	return name
+}
+
+// typeOf returns the source of a type expression from a file in the source
+// package, qualifying any types declared in the source package (if required)
+// and recording the imports of any packages referenced by the type
+func (g *generator) typeOf(expr ast.Expr, file *ast.File) string {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			for _, imp := range file.Imports {
+				importPath, _ := strconv.Unquote(imp.Path.Value)
+				name := packageName(importPath)
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				if name == pkg.Name {
+					g.addImport(importPath, name)
+				}
+			}
+		}
+		return false
+	})
+
+	if g.qualify {
+		expr = g.qualified(expr)
+	}
+	return g.print(expr)
+}
+
+// qualified returns a copy of a type expression in which the names of types
+// declared in the source package are qualified with the package name
+func (g *generator) qualified(expr ast.Expr) ast.Expr {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(x.Name) != nil {
+			return x
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(g.srcPkg), Sel: ast.NewIdent(x.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.qualified(x.X)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.qualified(x.Elt)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: x.Len, Elt: g.qualified(x.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.qualified(x.Key), Value: g.qualified(x.Value)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: x.Dir, Value: g.qualified(x.Value)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: g.qualifiedFields(x.Params), Results: g.qualifiedFields(x.Results)}
+	case *ast.StructType:
+		return &ast.StructType{Fields: g.qualifiedFields(x.Fields)}
+	case *ast.InterfaceType:
+		return &ast.InterfaceType{Methods: g.qualifiedFields(x.Methods)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: g.qualified(x.X), Index: g.qualified(x.Index)}
+	case *ast.IndexListExpr:
+		indices := make([]ast.Expr, len(x.Indices))
+		for i, idx := range x.Indices {
+			indices[i] = g.qualified(idx)
+		}
+		return &ast.IndexListExpr{X: g.qualified(x.X), Indices: indices}
+	default:
+		return expr
+	}
+}
+
+// qualifiedFields returns a copy of a field list with qualified field types
+func (g *generator) qualifiedFields(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+
+	result := &ast.FieldList{List: make([]*ast.Field, len(fl.List))}
+	for i, f := range fl.List {
+		result.List[i] = &ast.Field{Names: f.Names, Type: g.qualified(f.Type)}
+	}
+	return result
+}
+
+// print returns the source of an expression
+func (g *generator) print(expr ast.Expr) string {
+	buf := &bytes.Buffer{}
+	_ = printer.Fprint(buf, g.fset, expr)
+	return buf.String()
+}
+
+// values returns the argument or result values of a method.  Values without a
+// name (or with a blank name) are given a name formed from a prefix and their
+// position.
+func (g *generator) values(fl *ast.FieldList, file *ast.File, prefix string) []value {
+	if fl == nil {
+		return nil
+	}
+
+	result := []value{}
+	for _, f := range fl.List {
+		typ := g.typeOf(f.Type, file)
+		elem := typ
+		if ell, ok := f.Type.(*ast.Ellipsis); ok {
+			elem = "[]" + g.typeOf(ell.Elt, file)
+		}
+
+		names := []string{}
+		for _, id := range f.Names {
+			names = append(names, id.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, "")
+		}
+
+		for _, name := range names {
+			if name == "" || name == "_" {
+				name = prefix + strconv.Itoa(len(result)+1)
+			}
+			result = append(result, value{name: name, field: exported(name), typ: typ, elem: elem})
+		}
+	}
+	return result
+}
+
+// unique ensures that the names and fields of a list of values are unique.
+// Distinct names may yield the same field (e.g. "x" and "X") and a name given
+// to an unnamed value may be the same as that of a named value; the second and
+// any subsequent values with a name or field already used are given a numeric
+// suffix, e.g. "X", "X2".
+func unique(values []value) {
+	names := map[string]bool{}
+	fields := map[string]bool{}
+
+	for i, v := range values {
+		values[i].name = distinct(v.name, names)
+		values[i].field = distinct(v.field, fields)
+	}
+}
+
+// distinct returns a name that is not in a set of names already used, adding
+// it to the set.  If the name is already used, a numeric suffix is added,
+// e.g. "X2", "X3".
+func distinct(s string, used map[string]bool) string {
+	result := s
+	for n := 2; used[result]; n++ {
+		result = s + strconv.Itoa(n)
+	}
+	used[result] = true
+	return result
+}
+
+// exported returns a name with the first letter in upper case
+func exported(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// unexported returns a name with any leading upper case letters in lower case,
+// other than the last of more than one if followed by a lower case letter; e.g.
+// "Get" => "get", "ID" => "id", "URLFor" => "urlFor".
+func unexported(name string) string {
+	r := []rune(name)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	if n > 1 && n < len(r) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// fieldName returns the name of the MockFn field of the mock for a method
+func fieldName(m method, methods []method) string {
+	name := unexported(m.name)
+	if token.IsKeyword(name) {
+		return name + "Fn"
+	}
+	for _, other := range methods {
+		if other.name == name {
+			return name + "Fn"
+		}
+	}
+	return name
+}
+
+// fieldNames returns the names of the MockFn fields of the mock for a list of
+// methods.  Methods with names differing only in case (e.g. "URL" and "Url")
+// yield the same field name; the second and any subsequent fields with a name
+// already used (by a field or a method) are given a numeric suffix, e.g. "url",
+// "url2".
+func fieldNames(methods []method) []string {
+	used := map[string]bool{}
+	for _, m := range methods {
+		used[m.name] = true
+	}
+
+	result := make([]string, len(methods))
+	for i, m := range methods {
+		result[i] = distinct(fieldName(m, methods), used)
+	}
+	return result
+}
+
+// helperName returns the name of a helper method of the mock, such as Reset.
+// If the interface declares a method with the same name, the helper is named
+// with a "Mock" prefix; if the interface also declares a method with that
+// name, an error is returned.
+func helperName(iface string, name string, methods []method) (string, error) {
+	declared := func(name string) bool {
+		for _, m := range methods {
+			if m.name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case !declared(name):
+		return name, nil
+	case !declared("Mock" + name):
+		return "Mock" + name, nil
+	default:
+		return "", fmt.Errorf("%w: %s: interface declares both %s and Mock%s methods", ErrUnsupported, iface, name, name)
+	}
+}
+
+// writeImports writes the imports required by the generated mock
+func (g *generator) writeImports(w *bytes.Buffer) {
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	// standard library packages are imported in a separate group
+	// from other packages (identified by a domain in the first element)
+	std := func(p string) bool { return !strings.Contains(strings.Split(p, "/")[0], ".") }
+	sort.SliceStable(paths, func(i, j int) bool { return std(paths[i]) && !std(paths[j]) })
+
+	w.WriteString("import (\n")
+	for i, p := range paths {
+		if i > 0 && std(paths[i-1]) && !std(p) {
+			w.WriteString("\n")
+		}
+		if name := g.imports[p]; name != "" {
+			fmt.Fprintf(w, "\t%s %q\n", name, p)
+			continue
+		}
+		fmt.Fprintf(w, "\t%q\n", p)
+	}
+	w.WriteString(")\n\n")
+}
+
+// writeMock writes the declarations of the mock type, any tuple types for
+// the arguments and results of its methods, and the methods of the mock,
+// including the helper methods with the specified names that verify and
+// reset the expectations of the mock
+func (g *generator) writeMock(w *bytes.Buffer, methods []method, verify, reset string) {
+	iface := g.cfg.Interface
+	if g.qualify {
+		iface = g.srcPkg + "." + iface
+	}
+	mock := g.cfg.Mock
+
+	fields := fieldNames(methods)
+
+	fmt.Fprintf(w, "// %s is a mock implementation of %s.\n", mock, iface)
+	fmt.Fprintf(w, "type %s struct {\n", mock)
+	decls := &bytes.Buffer{}
+	funcs := &bytes.Buffer{}
+	for i, m := range methods {
+		args := g.values(m.fn.Params, m.file, "arg")
+		results := g.values(m.fn.Results, m.file, "result")
+
+		returnsErr := len(results) > 0 && results[len(results)-1].typ == "error"
+		if returnsErr {
+			results = results[:len(results)-1]
+		}
+
+		// arguments named for the receiver or local variables of the method
+		// are renamed to avoid shadowing them
+		for j, a := range args {
+			switch a.name {
+			case "mock", "result", "err":
+				args[j].name += "Arg"
+			}
+		}
+		unique(args)
+		unique(results)
+
+		argType := "any"
+		argExpr := ""
+		switch len(args) {
+		case 0:
+		case 1:
+			argType = args[0].elem
+			argExpr = args[0].name
+		default:
+			argType = mock + m.name + "Args"
+			inits := make([]string, len(args))
+			fmt.Fprintf(decls, "// %s holds the arguments of a call to %s.%s.\n", argType, iface, m.name)
+			fmt.Fprintf(decls, "type %s struct {\n", argType)
+			for j, a := range args {
+				fmt.Fprintf(decls, "\t%s %s\n", a.field, a.elem)
+				inits[j] = a.field + ": " + a.name
+			}
+			fmt.Fprintf(decls, "}\n\n")
+			argExpr = argType + "{" + strings.Join(inits, ", ") + "}"
+		}
+
+		resultType := "any"
+		switch len(results) {
+		case 0:
+		case 1:
+			resultType = results[0].typ
+		default:
+			resultType = mock + m.name + "Result"
+			fmt.Fprintf(decls, "// %s holds the result values of a call to %s.%s.\n", resultType, iface, m.name)
+			fmt.Fprintf(decls, "type %s struct {\n", resultType)
+			for _, r := range results {
+				fmt.Fprintf(decls, "\t%s %s\n", r.field, r.typ)
+			}
+			fmt.Fprintf(decls, "}\n\n")
+		}
+
+		fmt.Fprintf(w, "\t%s test.MockFn[%s, %s]\n", fields[i], argType, resultType)
+
+		g.writeMethod(funcs, m, fields[i], args, results, returnsErr, argExpr)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "var _ %s = (*%s)(nil)\n\n", iface, mock)
+
+	w.Write(decls.Bytes())
+	w.Write(funcs.Bytes())
+
+	fmt.Fprintf(w, "// %s returns an error if the expectations of any\n", verify)
+	fmt.Fprintf(w, "// method of the mock were not met; otherwise nil.\n")
+	fmt.Fprintf(w, "func (mock *%s) %s() error {\n", mock, verify)
+	fmt.Fprintf(w, "\terrs := []error{}\n")
+	for i, m := range methods {
+		fmt.Fprintf(w, "\tif err := mock.%s.ExpectationsWereMet(); err != nil {\n", fields[i])
+		fmt.Fprintf(w, "\t\terrs = append(errs, fmt.Errorf(\"%s: %%w\", err))\n", m.name)
+		fmt.Fprintf(w, "\t}\n")
+	}
+	fmt.Fprintf(w, "\treturn errors.Join(errs...)\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// %s resets the expectations of all methods of the mock.\n", reset)
+	fmt.Fprintf(w, "func (mock *%s) %s() {\n", mock, reset)
+	for _, field := range fields {
+		fmt.Fprintf(w, "\tmock.%s.Reset()\n", field)
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+// writeMethod writes a method of the mock, recording calls to the method with
+// the corresponding MockFn field of the mock
+func (g *generator) writeMethod(w *bytes.Buffer, m method, field string, args, results []value, returnsErr bool, argExpr string) {
+	params := make([]string, len(args))
+	for i, a := range args {
+		params[i] = a.name + " " + a.typ
+	}
+
+	returns := make([]string, 0, len(results)+1)
+	for _, r := range results {
+		returns = append(returns, r.typ)
+	}
+	if returnsErr {
+		returns = append(returns, "error")
+	}
+
+	sig := strings.Join(returns, ", ")
+	if len(returns) > 1 {
+		sig = "(" + sig + ")"
+	}
+
+	call := fmt.Sprintf("mock.%s.RecordCall(%s)", field, argExpr)
+
+	fmt.Fprintf(w, "func (mock *%s) %s(%s) %s {\n", g.cfg.Mock, m.name, strings.Join(params, ", "), sig)
+	switch {
+	case len(results) == 0 && !returnsErr:
+		fmt.Fprintf(w, "\t_, _ = %s\n", call)
+
+	case len(results) == 0:
+		fmt.Fprintf(w, "\t_, err := %s\n", call)
+		fmt.Fprintf(w, "\treturn err\n")
+
+	case len(results) == 1 && returnsErr:
+		fmt.Fprintf(w, "\treturn %s\n", call)
+
+	case len(results) == 1:
+		fmt.Fprintf(w, "\tresult, _ := %s\n", call)
+		fmt.Fprintf(w, "\treturn result\n")
+
+	default:
+		values := make([]string, len(results))
+		for i, r := range results {
+			values[i] = "result." + r.field
+		}
+		if returnsErr {
+			fmt.Fprintf(w, "\tresult, err := %s\n", call)
+			values = append(values, "err")
+		} else {
+			fmt.Fprintf(w, "\tresult, _ := %s\n", call)
+		}
+		fmt.Fprintf(w, "\treturn %s\n", strings.Join(values, ", "))
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
@@ -0,0 +1,178 @@
+package main //nolint: testpackage // tests rely on access to private functions
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	. "github.com/blugnu/test"
+)
+
+func TestGenerate(t *testing.T) {
+	With(t)
+
+	// the golden file is itself in the source package, so is excluded
+	golden := filepath.Join("testdata", "repo", "mock_repository.go")
+	exclude, _ := filepath.Abs(golden)
+
+	Run(Test("mock in source package", func() {
+		want, err := os.ReadFile(golden)
+		Expect(err).IsNil()
+
+		got, err := generate(config{Source: "testdata/repo", Interface: "Repository", Exclude: exclude})
+
+		Expect(err).IsNil()
+		Expect(string(got)).To(Equal(string(want)))
+	}))
+
+	Run(Test("golden file compiles", func() {
+		out, err := exec.Command("go", "vet", "./testdata/repo").CombinedOutput()
+
+		Expect(err, string(out)).IsNil()
+	}))
+
+	Run(Test("mock in other package", func() {
+		got, err := generate(config{
+			Source:    "testdata/repo",
+			Interface: "Reader",
+			Mock:      "MockReader",
+			Package:   "repo_test",
+			Import:    "example.com/repo",
+			Exclude:   exclude,
+		})
+
+		Expect(err).IsNil()
+		Expect(string(got)).To(Equal(`// Code generated by mockgen; DO NOT EDIT.
+
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"example.com/repo"
+	"github.com/blugnu/test"
+)
+
+// MockReader is a mock implementation of repo.Reader.
+type MockReader struct {
+	get test.MockFn[MockReaderGetArgs, *repo.User]
+}
+
+var _ repo.Reader = (*MockReader)(nil)
+
+// MockReaderGetArgs holds the arguments of a call to repo.Reader.Get.
+type MockReaderGetArgs struct {
+	Ctx context.Context
+	Id  string
+}
+
+func (mock *MockReader) Get(ctx context.Context, id string) (*repo.User, error) {
+	return mock.get.RecordCall(MockReaderGetArgs{Ctx: ctx, Id: id})
+}
+
+// ExpectationsWereMet returns an error if the expectations of any
+// method of the mock were not met; otherwise nil.
+func (mock *MockReader) ExpectationsWereMet() error {
+	errs := []error{}
+	if err := mock.get.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Get: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Reset resets the expectations of all methods of the mock.
+func (mock *MockReader) Reset() {
+	mock.get.Reset()
+}
+`))
+	}))
+
+	Run(Test("interface declaring a Reset method", func() {
+		got, err := generate(config{Source: "testdata/repo", Interface: "Cache", Exclude: exclude})
+
+		Expect(err).IsNil()
+		Expect(string(got)).To(ContainString(`func (mock *mockCache) Reset() {
+	_, _ = mock.reset.RecordCall()
+}`))
+		Expect(string(got)).To(ContainString(`// ExpectationsWereMet returns an error if the expectations of any
+// method of the mock were not met; otherwise nil.
+func (mock *mockCache) ExpectationsWereMet() error {`))
+		Expect(string(got)).To(ContainString(`// MockReset resets the expectations of all methods of the mock.
+func (mock *mockCache) MockReset() {
+	mock.get.Reset()
+	mock.reset.Reset()
+}`))
+	}))
+
+	type testcase struct {
+		cfg config
+		err error
+	}
+	Run(Testcases(
+		ForEach(func(tc testcase) {
+			tc.cfg.Exclude = exclude
+
+			_, err := generate(tc.cfg)
+
+			Expect(err).Is(tc.err)
+		}),
+		Case("no source", testcase{cfg: config{Source: "testdata", Interface: "Repository"}, err: ErrNoSource}),
+		Case("interface not found", testcase{cfg: config{Source: "testdata/repo", Interface: "Missing"}, err: ErrInterfaceNotFound}),
+		Case("not an interface", testcase{cfg: config{Source: "testdata/repo", Interface: "NotAnInterface"}, err: ErrNotAnInterface}),
+		Case("generic interface", testcase{cfg: config{Source: "testdata/repo", Interface: "Generic"}, err: ErrUnsupported}),
+		Case("embedded from other package", testcase{cfg: config{Source: "testdata/repo", Interface: "EmbedsExternal"}, err: ErrUnsupported}),
+		Case("helper name clash", testcase{cfg: config{Source: "testdata/repo", Interface: "ResetClash"}, err: ErrUnsupported}),
+		Case("other package without import", testcase{cfg: config{Source: "testdata/repo", Interface: "Reader", Package: "repo_test"}, err: ErrInvalidFlags}),
+	))
+}
+
+func TestRun(t *testing.T) {
+	With(t)
+
+	Run(Test("missing type", func() {
+		err := run([]string{"-source", "testdata/repo"}, &bytes.Buffer{})
+
+		Expect(err).Is(ErrInvalidFlags)
+	}))
+
+	Run(Test("output to stdout", func() {
+		stdout := &bytes.Buffer{}
+
+		err := run([]string{"-source", "testdata/repo", "-type", "Reader", "-mock", "fakeReader"}, stdout)
+
+		Expect(err).IsNil()
+		Expect(stdout.String()).To(ContainString("type fakeReader struct {"))
+	}))
+
+	Run(Test("output to file", func() {
+		out := filepath.Join(t.TempDir(), "mock.go")
+
+		err := run([]string{"-source", "testdata/repo", "-type", "Reader", "-out", out}, &bytes.Buffer{})
+
+		Expect(err).IsNil()
+		src, _ := os.ReadFile(out)
+		Expect(string(src)).To(ContainString("type mockReader struct {"))
+	}))
+}
+
+func TestUnexported(t *testing.T) {
+	With(t)
+
+	type testcase struct {
+		name   string
+		result string
+	}
+	Run(Testcases(
+		ForEach(func(tc testcase) {
+			Expect(unexported(tc.name)).To(Equal(tc.result))
+		}),
+		Case("single upper case letter", testcase{name: "Get", result: "get"}),
+		Case("all upper case", testcase{name: "ID", result: "id"}),
+		Case("leading acronym", testcase{name: "URLFor", result: "urlFor"}),
+		Case("already unexported", testcase{name: "get", result: "get"}),
+	))
+}
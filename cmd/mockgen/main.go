@@ -0,0 +1,92 @@
+// Command mockgen generates a mock implementation of an interface, built on
+// test.MockFn, for use with the blugnu/test package.
+//
+// The generated mock is a struct with a test.MockFn field for each method of
+// the interface, with methods implementing the interface by recording calls
+// with the corresponding field.  Methods accepting more than one argument are
+// mocked using a struct type with a field for each argument; methods returning
+// more than one value (in addition to an error) are mocked using a struct type
+// with a field for each result value.
+//
+// The mock also implements ExpectationsWereMet() and Reset(), satisfying the
+// test.Mock interface, so that it may be verified using test.ExpectationsWereMet()
+// or registered for automatic verification using test.Mocks().
+//
+// If the interface itself declares an ExpectationsWereMet or Reset method,
+// that method is mocked and the corresponding helper is instead named with a
+// "Mock" prefix, i.e. MockExpectationsWereMet() or MockReset(); the mock then
+// does not satisfy test.Mock.
+//
+// mockgen is intended to be used with go:generate, e.g:
+//
+//	//go:generate go run github.com/blugnu/test/cmd/mockgen -type Repository -out mock_repository_test.go
+//
+// # Flags
+//
+//	-type     name of the interface to be mocked (required)
+//	-source   directory of the package declaring the interface (default ".")
+//	-out      file to which the mock is written (default stdout)
+//	-mock     name of the mock type (default "mock" + the interface name)
+//	-package  name of the package of the generated mock (default: the package
+//	          declaring the interface)
+//	-import   import path of the package declaring the interface; required if
+//	          the mock is generated in a different package
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "mockgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses command line arguments and generates the mock they describe,
+// writing it to the output file specified or to stdout if none.
+func run(args []string, stdout io.Writer) error {
+	var cfg config
+
+	fs := flag.NewFlagSet("mockgen", flag.ContinueOnError)
+	fs.StringVar(&cfg.Interface, "type", "", "name of the interface to be mocked (required)")
+	fs.StringVar(&cfg.Source, "source", ".", "directory of the package declaring the interface")
+	fs.StringVar(&cfg.Mock, "mock", "", "name of the mock type (default \"mock\" + the interface name)")
+	fs.StringVar(&cfg.Package, "package", "", "name of the package of the generated mock (default: the package declaring the interface)")
+	fs.StringVar(&cfg.Import, "import", "", "import path of the package declaring the interface")
+	out := fs.String("out", "", "file to which the mock is written (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if cfg.Interface == "" {
+		return fmt.Errorf("%w: -type is required", ErrInvalidFlags)
+	}
+
+	if *out != "" {
+		// the output file is excluded from the source, since it may be
+		// a previously generated mock in the same package
+		if abs, err := filepath.Abs(*out); err == nil {
+			cfg.Exclude = abs
+		}
+	}
+
+	src, err := generate(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+
+	const perm = 0o644
+	return os.WriteFile(*out, src, perm)
+}
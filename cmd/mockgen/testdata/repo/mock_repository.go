@@ -0,0 +1,182 @@
+// Code generated by mockgen; DO NOT EDIT.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdio "io"
+	"time"
+
+	"github.com/blugnu/test"
+)
+
+// mockRepository is a mock implementation of Repository.
+type mockRepository struct {
+	get    test.MockFn[mockRepositoryGetArgs, *User]
+	find   test.MockFn[mockRepositoryFindArgs, []*User]
+	save   test.MockFn[*User, any]
+	count  test.MockFn[any, int]
+	stats  test.MockFn[time.Time, mockRepositoryStatsResult]
+	export test.MockFn[mockRepositoryExportArgs, mockRepositoryExportResult]
+	move   test.MockFn[mockRepositoryMoveArgs, mockRepositoryMoveResult]
+	url    test.MockFn[any, string]
+	url2   test.MockFn[any, string]
+	close  test.MockFn[any, any]
+	typeFn test.MockFn[any, string]
+}
+
+var _ Repository = (*mockRepository)(nil)
+
+// mockRepositoryGetArgs holds the arguments of a call to Repository.Get.
+type mockRepositoryGetArgs struct {
+	Ctx context.Context
+	Id  string
+}
+
+// mockRepositoryFindArgs holds the arguments of a call to Repository.Find.
+type mockRepositoryFindArgs struct {
+	Ctx    context.Context
+	Filter map[string]string
+	Limit  int
+}
+
+// mockRepositoryStatsResult holds the result values of a call to Repository.Stats.
+type mockRepositoryStatsResult struct {
+	Count int
+	Size  int64
+}
+
+// mockRepositoryExportArgs holds the arguments of a call to Repository.Export.
+type mockRepositoryExportArgs struct {
+	Arg1 stdio.Writer
+	Arg2 []string
+}
+
+// mockRepositoryExportResult holds the result values of a call to Repository.Export.
+type mockRepositoryExportResult struct {
+	Result1 int
+	Result2 int
+}
+
+// mockRepositoryMoveArgs holds the arguments of a call to Repository.Move.
+type mockRepositoryMoveArgs struct {
+	Id   string
+	Id2  string
+	Arg3 int
+	Arg4 int
+}
+
+// mockRepositoryMoveResult holds the result values of a call to Repository.Move.
+type mockRepositoryMoveResult struct {
+	N  int
+	N2 int
+}
+
+func (mock *mockRepository) Get(ctx context.Context, id string) (*User, error) {
+	return mock.get.RecordCall(mockRepositoryGetArgs{Ctx: ctx, Id: id})
+}
+
+func (mock *mockRepository) Find(ctx context.Context, filter map[string]string, limit int) ([]*User, error) {
+	return mock.find.RecordCall(mockRepositoryFindArgs{Ctx: ctx, Filter: filter, Limit: limit})
+}
+
+func (mock *mockRepository) Save(u *User) error {
+	_, err := mock.save.RecordCall(u)
+	return err
+}
+
+func (mock *mockRepository) Count() int {
+	result, _ := mock.count.RecordCall()
+	return result
+}
+
+func (mock *mockRepository) Stats(since time.Time) (int, int64, error) {
+	result, err := mock.stats.RecordCall(since)
+	return result.Count, result.Size, err
+}
+
+func (mock *mockRepository) Export(arg1 stdio.Writer, arg2 ...string) (int, int) {
+	result, _ := mock.export.RecordCall(mockRepositoryExportArgs{Arg1: arg1, Arg2: arg2})
+	return result.Result1, result.Result2
+}
+
+func (mock *mockRepository) Move(id string, Id string, arg3 int, arg4 int) (int, int, error) {
+	result, err := mock.move.RecordCall(mockRepositoryMoveArgs{Id: id, Id2: Id, Arg3: arg3, Arg4: arg4})
+	return result.N, result.N2, err
+}
+
+func (mock *mockRepository) URL() string {
+	result, _ := mock.url.RecordCall()
+	return result
+}
+
+func (mock *mockRepository) Url() string {
+	result, _ := mock.url2.RecordCall()
+	return result
+}
+
+func (mock *mockRepository) Close() {
+	_, _ = mock.close.RecordCall()
+}
+
+func (mock *mockRepository) Type() string {
+	result, _ := mock.typeFn.RecordCall()
+	return result
+}
+
+// ExpectationsWereMet returns an error if the expectations of any
+// method of the mock were not met; otherwise nil.
+func (mock *mockRepository) ExpectationsWereMet() error {
+	errs := []error{}
+	if err := mock.get.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Get: %w", err))
+	}
+	if err := mock.find.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Find: %w", err))
+	}
+	if err := mock.save.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Save: %w", err))
+	}
+	if err := mock.count.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Count: %w", err))
+	}
+	if err := mock.stats.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Stats: %w", err))
+	}
+	if err := mock.export.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Export: %w", err))
+	}
+	if err := mock.move.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Move: %w", err))
+	}
+	if err := mock.url.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("URL: %w", err))
+	}
+	if err := mock.url2.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Url: %w", err))
+	}
+	if err := mock.close.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Close: %w", err))
+	}
+	if err := mock.typeFn.ExpectationsWereMet(); err != nil {
+		errs = append(errs, fmt.Errorf("Type: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// Reset resets the expectations of all methods of the mock.
+func (mock *mockRepository) Reset() {
+	mock.get.Reset()
+	mock.find.Reset()
+	mock.save.Reset()
+	mock.count.Reset()
+	mock.stats.Reset()
+	mock.export.Reset()
+	mock.move.Reset()
+	mock.url.Reset()
+	mock.url2.Reset()
+	mock.close.Reset()
+	mock.typeFn.Reset()
+}
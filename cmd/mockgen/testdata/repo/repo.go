@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"context"
+	stdio "io"
+	"time"
+)
+
+type User struct {
+	ID   string
+	Name string
+}
+
+type Reader interface {
+	Get(ctx context.Context, id string) (*User, error)
+}
+
+// Repository is a repository of users.
+type Repository interface {
+	Reader
+	Find(ctx context.Context, filter map[string]string, limit int) ([]*User, error)
+	Save(u *User) error
+	Count() int
+	Stats(since time.Time) (count int, size int64, err error)
+	Export(stdio.Writer, ...string) (int, int)
+	Move(id, Id string, _, _ int) (n, N int, err error)
+	URL() string
+	Url() string
+	Close()
+	Type() string
+}
+
+// Cache is a cache of users that may be reset.
+type Cache interface {
+	Get(id string) (*User, bool)
+	Reset()
+}
+
+type ResetClash interface {
+	Reset()
+	MockReset()
+}
+
+type NotAnInterface struct{}
+
+type Generic[T any] interface {
+	Get() T
+}
+
+type EmbedsExternal interface {
+	stdio.Reader
+}
@@ -25,12 +25,26 @@ func HaveContextKey[K comparable](k K) *contexts.KeyMatcher[K] {
 //
 // The key type (K) must be comparable. The matcher will fail if the key is not
 // present in the context or if the value does not match the expected value.
-// The value type (V) can be any type.
+// The value type (V) can be any type, including a matcher.
 //
 // The matcher uses reflect.DeepEqual to compare the expected value with any value
 // in the context for the specified key; this may be overridden by supplying a
 // custom comparison function in the options.
 //
+// Alternatively, the expected value may be a matcher; the value in the context is
+// then tested using that matcher, e.g.:
+//
+//	Expect(ctx).To(HaveContextValue(requestIDKey, MatchRegEx(uuidPattern)))
+//
+// When a matcher is supplied, the type of the value in the context must be
+// compatible with the matcher and any custom comparison function is ignored.
+//
+// If the key is not present in the context, the test failure report lists the
+// keys that are present in the context.  Any key having the same value as the
+// expected key but a different type is identified; this is a common cause of
+// a missing key, e.g. where a plain string has been used for a key instead of
+// a custom key type.
+//
 // # Supported Options
 //
 //	func(V, V) bool            // a custom comparison function to compare values
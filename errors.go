@@ -2,20 +2,26 @@ package test
 
 import (
 	"errors"
+
+	"github.com/blugnu/test/test"
 )
 
 var (
 	// general errors
-	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrInvalidArgument  = test.ErrInvalidArgument
 	ErrInvalidOperation = errors.New("invalid operation")
 
 	// mock and fake errors
 	ErrExpectationsNotMet = errors.New("expectations not met")
 	ErrExpectedArgs       = errors.New("arguments were expected but not recorded")
+	ErrMissingCalls       = errors.New("expected calls were not made")
+	ErrNoCapturedArgs     = errors.New("no arguments captured")
 	ErrNoResultForArgs    = errors.New("no result for arguments")
+	ErrOutOfOrder         = errors.New("call made out of order")
 	ErrUnexpectedArgs     = errors.New("the arguments recorded did not match those expected")
 	ErrUnexpectedCall     = errors.New("unexpected call")
 	ErrResultNotUsed      = errors.New("result not used")
+	ErrResultsExhausted   = errors.New("all results in sequence have been returned")
 
 	// recording errors
 	ErrRecordingFailed                 = errors.New("recording failed")
@@ -2,6 +2,8 @@ package test
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/blugnu/test/test"
 )
@@ -16,10 +18,9 @@ import (
 //
 // # Limitations
 //
-// No mechanism is provided for configuring expected calls, capturing or testing
-// for expected arguments, or returning different values for multiple calls.
-// FakeResult[R] is for simple cases where a fake function or method consistently
-// returns a specific result.
+// No mechanism is provided for configuring expected calls or capturing or testing
+// for expected arguments.  FakeResult[R] is for simple cases where a fake function
+// or method returns a specific result (or a sequence of results, using ThenReturns).
 //
 // For cases requiring more advanced capabilities, consider using test.MockFn[A, R].
 //
@@ -59,8 +60,8 @@ import (
 //	}
 //
 //	func (fake *fakeMyMethod) MyMethod() (int, string, error) {
-//		fn := fake.fakeMyMethodFn
-//		return fn.Result.age, fn.Result.name, fn.Err
+//		result, err := fake.fakeMyMethodFn.Get()
+//		return result.age, result.name, err
 //	}
 //
 // # Return Value(s) with No Error
@@ -94,14 +95,151 @@ import (
 // parameter R can be any type, but using FakeResult[error] makes it clear that the
 // fake is for a function that returns an error, even though the Result field, to which the
 // type parameter 'error' relates, is ignored.
+//
+// # Concurrency
+//
+// The methods of a FakeResult are safe for concurrent use; a fake that may be
+// reconfigured while it is being used concurrently should obtain the result and
+// error using Get() rather than reading the Result and Err fields directly.
+//
+// A FakeResult may be copied.  Any sequence of results (see ThenReturns) and
+// additional behaviour (see WillPanic, WillDelay and WillBlockUntil) are held by
+// reference and shared by a copy with the original, so that obtaining a result
+// from a copy advances the sequence of the original (and vice versa).
 type FakeResult[R any] struct {
 	Result R
 	Err    error
+
+	// state holds any sequence of results and additional behaviour of the fake,
+	// guarded by a mutex of its own; nil until the fake is so configured
+	state *fakeState[R]
+}
+
+// fakeState holds the sequence of results and additional behaviour of a
+// FakeResult, with a mutex guarding them (and the Result and Err fields of
+// the FakeResult).
+type fakeState[R any] struct {
+	mu sync.Mutex
+
+	// behaviour is any additional behaviour of the fake (delaying, blocking or
+	// panicking), applied by Get(); nil if the fake simply returns Result and Err
+	behaviour *behaviour
+
+	// seq holds any subsequent results configured using ThenReturns; nil if the
+	// fake always returns Result and Err
+	seq *fakeSequence[R]
 }
 
-// Reset resets the fake to its zero value.
+// fakeStateMu guards the state of all FakeResult values, and the Result and Err
+// fields of any FakeResult without state.  It is held only to obtain the state
+// of a fake (or to read or write the fields of a fake without state); a fake
+// with state is otherwise guarded by the mutex of its state.
+var fakeStateMu sync.Mutex
+
+// lock locks the fake, returning its state and a function to unlock the fake.
+// If the fake has no state, it is initialised if create is true; otherwise the
+// state returned is nil.
+func (fake *FakeResult[R]) lock(create bool) (*fakeState[R], func()) {
+	fakeStateMu.Lock()
+
+	st := fake.state
+	if st == nil && create {
+		st = &fakeState[R]{}
+		fake.state = st
+	}
+	if st == nil {
+		return nil, fakeStateMu.Unlock
+	}
+	fakeStateMu.Unlock()
+
+	st.mu.Lock()
+	return st, st.mu.Unlock
+}
+
+// Get returns the result and error of the fake, e.g.:
+//
+//	func (fake *fakeMyMethod) MyMethod() (int, error) {
+//		return fake.myMethodFn.Get()
+//	}
+//
+// If the fake is configured with a sequence of results (using ThenReturns), each
+// call returns the next result in the sequence.
+//
+// If the fake is configured to delay, block or panic (using WillDelay,
+// WillBlockUntil or WillPanic), Get does so before returning.
+func (fake *FakeResult[R]) Get() (R, error) {
+	st, unlock := fake.lock(false)
+	result, err := fake.next(st)
+	var b *behaviour
+	if st != nil {
+		b = st.behaviour
+	}
+	unlock()
+
+	if b == nil {
+		return result, err
+	}
+	return applyBehaviour[any](b, true, nil, result, err)
+}
+
+// WillPanic configures the fake to panic with a specified value when the
+// result is obtained using Get().
+func (fake *FakeResult[R]) WillPanic(v any) {
+	st, unlock := fake.lock(true)
+	defer unlock()
+
+	b := st.ensureBehaviour()
+	b.panics = true
+	b.panicValue = v
+}
+
+// WillDelay configures the fake to wait for a specified duration before
+// returning the result from Get().
+func (fake *FakeResult[R]) WillDelay(d time.Duration) *FakeResult[R] {
+	st, unlock := fake.lock(true)
+	defer unlock()
+
+	st.ensureBehaviour().delay = d
+	return fake
+}
+
+// WillBlockUntil configures the fake to block in Get() until a context is done
+// or a channel is closed (or receives a value).  The argument must be a
+// context.Context, a chan struct{} or a <-chan struct{}; any other value, a nil
+// channel or a context that can never be done (e.g. context.Background()) causes
+// a panic with ErrInvalidArgument.
+func (fake *FakeResult[R]) WillBlockUntil(v any) *FakeResult[R] {
+	until := blockUntil("WillBlockUntil", v)
+
+	st, unlock := fake.lock(true)
+	defer unlock()
+
+	st.ensureBehaviour().until = until
+	return fake
+}
+
+// ensureBehaviour returns the behaviour of a fake, initialising it if
+// required; the caller must hold the lock on the fake
+func (st *fakeState[R]) ensureBehaviour() *behaviour {
+	if st.behaviour == nil {
+		st.behaviour = &behaviour{}
+	}
+	return st.behaviour
+}
+
+// Reset resets the result and error of the fake to their zero values and
+// removes any sequence of results or additional behaviour (also from any copy
+// of the fake sharing them).
 func (fake *FakeResult[R]) Reset() {
-	*fake = FakeResult[R]{}
+	st, unlock := fake.lock(false)
+	defer unlock()
+
+	fake.Result = *new(R)
+	fake.Err = nil
+	if st != nil {
+		st.behaviour = nil
+		st.seq = nil
+	}
 }
 
 // Returns sets the result value and/or error to be returned by the fake.
@@ -127,6 +265,117 @@ func (fake *FakeResult[R]) Reset() {
 func (fake *FakeResult[R]) Returns(v ...any) {
 	test.T().Helper()
 
+	_, unlock := fake.lock(false)
+	defer unlock()
+
+	ret := fakeReturn[R]{Result: fake.Result, Err: fake.Err}
+	ret.set(v...)
+	fake.Result, fake.Err = ret.Result, ret.Err
+}
+
+// ThenReturns adds a result value and/or error to be returned by the fake on a
+// subsequent call to Get(), establishing a sequence of results.  The values are
+// specified as for Returns.
+//
+// The first call to Get() returns the result and error set using Returns (or the
+// Result and Err fields); each subsequent call returns the next result in the
+// sequence.  Once the sequence is exhausted, the last result is repeated unless
+// the fake is configured using FailWhenExhausted.
+//
+// e.g. to fake a function that fails twice before succeeding:
+//
+//	fake := &FakeResult[int]{}
+//	fake.Returns(errTimeout)
+//	fake.ThenReturns(errTimeout).ThenReturns(42)
+//
+// A sequence of results is only returned by Get() (or MockFn.ResultFor());
+// reading the Result and Err fields directly yields the first result.
+func (fake *FakeResult[R]) ThenReturns(v ...any) *FakeResult[R] {
+	test.T().Helper()
+
+	st, unlock := fake.lock(true)
+	defer unlock()
+
+	next := fakeReturn[R]{}
+	next.set(v...)
+
+	seq := st.ensureSequence()
+	seq.results = append(seq.results, next)
+
+	return fake
+}
+
+// FailWhenExhausted configures a fake with a sequence of results to return
+// ErrResultsExhausted (with the zero value of R) from any call to Get() made
+// once all results in the sequence have been returned, rather than repeating
+// the last result.
+func (fake *FakeResult[R]) FailWhenExhausted() *FakeResult[R] {
+	st, unlock := fake.lock(true)
+	defer unlock()
+
+	st.ensureSequence().failWhenExhausted = true
+	return fake
+}
+
+// fakeSequence holds the subsequent results of a FakeResult configured with a
+// sequence of results, and the number of results returned so far.
+type fakeSequence[R any] struct {
+	// results are the results following the first (which is held in the Result
+	// and Err fields of the FakeResult)
+	results []fakeReturn[R]
+
+	// calls is the number of calls to Get()
+	calls int
+
+	// failWhenExhausted is true if ErrResultsExhausted is to be returned once all
+	// results have been returned
+	failWhenExhausted bool
+}
+
+// ensureSequence returns the sequence of results of a fake, initialising it
+// if required; the caller must hold the lock on the fake
+func (st *fakeState[R]) ensureSequence() *fakeSequence[R] {
+	if st.seq == nil {
+		st.seq = &fakeSequence[R]{}
+	}
+	return st.seq
+}
+
+// next returns the next result and error of the fake (with state st), advancing
+// any sequence of results; the caller must hold the lock on the fake
+func (fake *FakeResult[R]) next(st *fakeState[R]) (R, error) {
+	if st == nil || st.seq == nil {
+		return fake.Result, fake.Err
+	}
+	seq := st.seq
+
+	n := seq.calls
+	seq.calls++
+
+	switch {
+	case n == 0:
+		return fake.Result, fake.Err
+	case n <= len(seq.results):
+		return seq.results[n-1].Result, seq.results[n-1].Err
+	case seq.failWhenExhausted:
+		return *new(R), fmt.Errorf("%w: %d results configured, call %d", ErrResultsExhausted, len(seq.results)+1, n+1)
+	default:
+		last := seq.results[len(seq.results)-1]
+		return last.Result, last.Err
+	}
+}
+
+// fakeReturn holds a result value and error returned by a fake
+type fakeReturn[R any] struct {
+	Result R
+	Err    error
+}
+
+// set sets the result value and/or error from the values specified to Returns
+// or ThenReturns
+func (fake *fakeReturn[R]) set(v ...any) {
+	test.T().Helper()
+
 	resultSet := false
 	errSet := false
 	for _, r := range v {
@@ -1,8 +1,10 @@
 package test_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	. "github.com/blugnu/test"
 )
@@ -20,6 +22,165 @@ func TestFakeResult(t *testing.T) {
 	Expect(sut).Is(FakeResult[int]{})
 }
 
+func TestFakeResult_Get(t *testing.T) {
+	With(t)
+
+	// ARRANGE
+	err := errors.New("faked error")
+	sut := FakeResult[int]{Result: 42, Err: err}
+
+	// ACT
+	result, got := sut.Get()
+
+	// ASSERT
+	Expect(result).To(Equal(42))
+	Expect(got).Is(err)
+}
+
+func TestFakeResult_ThenReturns(t *testing.T) {
+	With(t)
+
+	errTimeout := errors.New("timeout")
+
+	Run(Test("sequence repeats last result", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		sut.Returns(errTimeout)
+		sut.ThenReturns(errTimeout).ThenReturns(42)
+
+		// ACT
+		_, err1 := sut.Get()
+		_, err2 := sut.Get()
+		r3, err3 := sut.Get()
+		r4, err4 := sut.Get()
+
+		// ASSERT
+		Expect(err1).Is(errTimeout)
+		Expect(err2).Is(errTimeout)
+		Expect(err3).IsNil()
+		Expect(r3).To(Equal(42))
+		Expect(err4).IsNil()
+		Expect(r4).To(Equal(42))
+	}))
+
+	Run(Test("copy shares sequence", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		sut.Returns(1)
+		sut.ThenReturns(2).ThenReturns(3)
+		cpy := sut
+
+		// ACT
+		r1, _ := sut.Get()
+		r2, _ := cpy.Get()
+		r3, _ := sut.Get()
+
+		// ASSERT
+		Expect(r1).To(Equal(1))
+		Expect(r2).To(Equal(2))
+		Expect(r3).To(Equal(3))
+	}))
+
+	Run(Test("fail when exhausted", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		sut.Returns(1)
+		sut.ThenReturns(2).FailWhenExhausted()
+
+		// ACT
+		r1, _ := sut.Get()
+		r2, _ := sut.Get()
+		r3, err := sut.Get()
+
+		// ASSERT
+		Expect(r1).To(Equal(1))
+		Expect(r2).To(Equal(2))
+		Expect(r3).To(Equal(0))
+		Expect(err).Is(ErrResultsExhausted)
+		Expect(err.Error()).To(ContainString("2 results configured, call 3"))
+	}))
+
+	Run(Test("reset", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		sut.Returns(1)
+		sut.ThenReturns(2)
+
+		// ACT
+		sut.Reset()
+		sut.Returns(3)
+
+		// ASSERT
+		r1, _ := sut.Get()
+		r2, _ := sut.Get()
+		Expect(r1).To(Equal(3))
+		Expect(r2).To(Equal(3))
+	}))
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "invalid value",
+			Act: func() {
+				sut := FakeResult[int]{}
+				sut.ThenReturns("invalid")
+			},
+			Assert: func(result *R) {
+				result.Expect(ErrInvalidOperation, "only values of type int or error (or nil) may be specified")
+			},
+		},
+	}...))
+}
+
+func TestFakeResult_Behaviours(t *testing.T) {
+	With(t)
+
+	Run(Test("will panic", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		sut.WillPanic("fake panic")
+		defer Expect(Panic("fake panic")).DidOccur()
+
+		// ACT
+		_, _ = sut.Get()
+	}))
+
+	Run(Test("will delay", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		sut.WillDelay(20 * time.Millisecond).Returns(42)
+
+		// ACT
+		start := time.Now()
+		result, _ := sut.Get()
+
+		// ASSERT
+		Expect(result).To(Equal(42))
+		Expect(time.Since(start) >= 20*time.Millisecond).To(BeTrue())
+	}))
+
+	Run(Test("will block until", func() {
+		// ARRANGE
+		ch := make(chan struct{})
+		sut := FakeResult[int]{}
+		sut.WillBlockUntil(ch).Returns(42)
+		time.AfterFunc(10*time.Millisecond, func() { close(ch) })
+
+		// ACT
+		result, _ := sut.Get()
+
+		// ASSERT
+		Expect(result).To(Equal(42))
+	}))
+
+	Run(Test("will block until context never done", func() {
+		// ARRANGE
+		sut := FakeResult[int]{}
+		defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+		// ACT
+		sut.WillBlockUntil(context.Background())
+	}))
+}
+
 func TestFakeResult_Returns(t *testing.T) {
 	With(t)
 
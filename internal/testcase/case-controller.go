@@ -1,5 +1,10 @@
 package testcase
 
+import (
+	"slices"
+	"time"
+)
+
 type Controller[T any] struct {
 	// idx is the index of the test case in the list of test cases
 	idx int
@@ -8,6 +13,9 @@ type Controller[T any] struct {
 	// and in any Before/After scaffolding functions
 	name string
 
+	// group is the path of names of the (nested) groups containing the test case
+	group []string
+
 	// data is the test case data
 	data T
 
@@ -19,6 +27,10 @@ type Controller[T any] struct {
 
 	// parallel is used to indicate whether the test case should be run in parallel
 	parallel bool
+
+	// timeout is the time allowed for the test case to complete; if zero, the
+	// timeout of the runner (if any) applies
+	timeout time.Duration
 }
 
 func NewController[T any](data T, index int, name string) Controller[T] {
@@ -28,5 +40,13 @@ func NewController[T any](data T, index int, name string) Controller[T] {
 		data:  data,
 		debug: IsDebugging(data),
 		skip:  IsSkipping(data),
+
+		timeout: TimeoutOf(data),
 	}
 }
+
+// inGroup returns true if the test case is in a group identified by a path of
+// group names, either directly or in a group nested within it.
+func (c Controller[T]) inGroup(path []string) bool {
+	return len(c.group) >= len(path) && slices.Equal(c.group[:len(path)], path)
+}
@@ -0,0 +1,390 @@
+package testcase
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a test case loaded from a test data file, holding the values of
+// fields of the test case and the name of the test case (if any).
+type Record struct {
+	Name   string
+	Values []Value
+}
+
+// Value is the value of a field of a test case loaded from a test data file.
+// The value is held in its encoded form and is decoded according to the type
+// of the field when the record is applied to a test case.
+type Value struct {
+	Field  string
+	decode func(reflect.Type) (reflect.Value, error)
+}
+
+// JSONValue returns a Value for a field holding a JSON encoded value.
+func JSONValue(field string, data json.RawMessage) Value {
+	return Value{
+		Field: field,
+		decode: func(t reflect.Type) (reflect.Value, error) {
+			v := reflect.New(t)
+			if err := json.Unmarshal(data, v.Interface()); err != nil {
+				return v, err
+			}
+			return v.Elem(), nil
+		},
+	}
+}
+
+// TextValue returns a Value for a field holding a value in text form.
+//
+// Text is decoded according to the type of the field:
+//
+//   - types implementing encoding.TextUnmarshaler are decoded using
+//     UnmarshalText
+//   - string (and interface) fields are set to the text
+//   - time.Duration fields are parsed using time.ParseDuration
+//   - bool and numeric fields are parsed using the strconv package
+//   - other types (e.g. slices, maps, structs) are decoded as JSON
+//
+// Empty text decodes as the zero value of any type other than string.
+func TextValue(field string, text string) Value {
+	return Value{
+		Field: field,
+		decode: func(t reflect.Type) (reflect.Value, error) {
+			return parseText(text, t)
+		},
+	}
+}
+
+// parseText decodes a value of a given type from text
+func parseText(s string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return v, u.UnmarshalText([]byte(s))
+	}
+
+	switch {
+	case t.Kind() == reflect.String:
+		v.SetString(s)
+		return v, nil
+	case t.Kind() == reflect.Interface && reflect.TypeOf(s).AssignableTo(t):
+		v.Set(reflect.ValueOf(s))
+		return v, nil
+	case s == "":
+		return v, nil
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == reflect.TypeOf(time.Duration(0)) {
+			var d time.Duration
+			d, err = time.ParseDuration(s)
+			v.SetInt(int64(d))
+			break
+		}
+		var i int64
+		i, err = strconv.ParseInt(s, 0, t.Bits())
+		v.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		u, err = strconv.ParseUint(s, 0, t.Bits())
+		v.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, t.Bits())
+		v.SetFloat(f)
+
+	default:
+		err = json.Unmarshal([]byte(s), v.Addr().Interface())
+	}
+
+	return v, err
+}
+
+// Apply returns a copy of a test case with the fields of the test case set to
+// the values in the record.
+//
+// A value is applied to the field with the same name as the value or, if
+// there is no such field, a name that is equal to the value name ignoring
+// case.  A "name" value is used to name the test case and need not have a
+// corresponding field; any other value with no corresponding field is an
+// error.
+func (r Record) Apply(tc any) (any, error) {
+	if reflect.TypeOf(tc) == nil || reflect.TypeOf(tc).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrNotAStruct, tc)
+	}
+
+	v := reflect.New(reflect.TypeOf(tc)).Elem()
+	v.Set(reflect.ValueOf(tc))
+
+	for _, val := range r.Values {
+		f, err := field(v, val.Field)
+		switch {
+		case err != nil && strings.EqualFold(val.Field, "name"):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		fv, err := val.decode(f.Type())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrIncompatible, val.Field, err)
+		}
+		f.Set(fv)
+	}
+	return v.Interface(), nil
+}
+
+// nameOf returns the value of any "name" value in a set of text values
+func nameOf(fields []string, values []string) string {
+	for i, f := range fields {
+		if strings.EqualFold(f, "name") {
+			return values[i]
+		}
+	}
+	return ""
+}
+
+// ParseJSON parses test case records from JSON.  The JSON must be either an
+// array of objects, each of which is a record, or an object with a member for
+// each record, keyed by the name of the test case:
+//
+//	[ { "name": "first", "input": 1 }, { "name": "second", "input": 2 } ]
+//
+//	{ "first": { "input": 1 }, "second": { "input": 2 } }
+//
+// In an array, a test case is named by any "name" member of the record.
+// Records are returned in the order in which they appear in the JSON.
+func ParseJSON(data []byte) ([]Record, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+
+	result := []Record{}
+	switch tok {
+	case json.Delim('['):
+		for i := 1; dec.More(); i++ {
+			rec, err := parseJSONRecord(dec, "")
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", i, err)
+			}
+			result = append(result, rec)
+		}
+
+	case json.Delim('{'):
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidData, err)
+			}
+			name, _ := tok.(string)
+			rec, err := parseJSONRecord(dec, name)
+			if err != nil {
+				return nil, fmt.Errorf("record %q: %w", name, err)
+			}
+			result = append(result, rec)
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: expected an array or object", ErrInvalidData)
+	}
+
+	return result, nil
+}
+
+// parseJSONRecord parses a record from the next object in a JSON decoder.
+// Members are returned in the order in which they appear in the object.
+func parseJSONRecord(dec *json.Decoder, name string) (Record, error) {
+	rec := Record{Name: name}
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return rec, fmt.Errorf("%w: expected an object", ErrInvalidData)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return rec, fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		key, _ := tok.(string)
+
+		var data json.RawMessage
+		if err := dec.Decode(&data); err != nil {
+			return rec, fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+
+		if strings.EqualFold(key, "name") && rec.Name == "" {
+			_ = json.Unmarshal(data, &rec.Name)
+		}
+		rec.Values = append(rec.Values, JSONValue(key, data))
+	}
+
+	// consume the closing delimiter of the object
+	if _, err := dec.Token(); err != nil {
+		return rec, fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	return rec, nil
+}
+
+// ParseCSV parses test case records from CSV.  The first row is a header
+// naming the field of the test case in each column; each subsequent row is a
+// record.  A test case is named by any "name" column of the record.  Lines
+// beginning with '#' are comments and are ignored.
+func ParseCSV(r io.Reader) ([]Record, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrInvalidData, err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	result := []Record{}
+	for i := 1; ; i++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d: %w", ErrInvalidData, i, err)
+		}
+
+		rec := Record{Name: nameOf(header, row)}
+		for j, f := range header {
+			rec.Values = append(rec.Values, TextValue(f, row[j]))
+		}
+		result = append(result, rec)
+	}
+	return result, nil
+}
+
+// ParseArchive parses a test case record from a txtar-style archive: a
+// sequence of sections, each introduced by a marker line of the form
+//
+//	-- field --
+//
+// followed by the text of the value of that field, up to the next marker line
+// or the end of the archive.  The final newline of each value is removed.
+// Any text before the first marker is a comment and is ignored.
+func ParseArchive(name string, data []byte) Record {
+	rec := Record{Name: name}
+
+	var (
+		fld   string
+		text  []string
+		inFld bool
+	)
+	flush := func() {
+		if inFld {
+			rec.Values = append(rec.Values, TextValue(fld, strings.Join(text, "\n")))
+		}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if f, ok := archiveMarker(line); ok {
+			flush()
+			fld, text, inFld = f, nil, true
+			continue
+		}
+		text = append(text, line)
+	}
+	flush()
+
+	return rec
+}
+
+// archiveMarker returns the field named by a marker line in an archive and
+// true, or false if the line is not a marker line
+func archiveMarker(line string) (string, bool) {
+	if !strings.HasPrefix(line, "-- ") || !strings.HasSuffix(line, " --") || len(line) < len("-- x --") {
+		return "", false
+	}
+	f := strings.TrimSpace(line[len("-- ") : len(line)-len(" --")])
+	return f, f != ""
+}
+
+// LoadJSON loads test case records from a JSON file (see: ParseJSON).
+func LoadJSON(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ParseJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
+
+// LoadCSV loads test case records from a CSV file (see: ParseCSV).
+func LoadCSV(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result, err := ParseCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
+
+// LoadDir loads test case records from a directory in which each file is a
+// txtar-style archive holding a single test case (see: ParseArchive).  Each
+// test case is named for its file, without any extension.
+//
+// Files are loaded in name order; subdirectories and files with names
+// beginning with '.' are ignored.
+func LoadDir(path string) ([]Record, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Record{}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(path, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		result = append(result, ParseArchive(name, data))
+	}
+	return result, nil
+}
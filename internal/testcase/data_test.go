@@ -0,0 +1,282 @@
+package testcase_test
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/blugnu/test"
+	"github.com/blugnu/test/internal/testcase"
+)
+
+func TestRecord_Apply(t *testing.T) {
+	With(t)
+
+	type nested struct {
+		A int `json:"a"`
+	}
+	type tc struct {
+		Input    string
+		count    int
+		enabled  bool
+		ratio    float64
+		size     uint8
+		timeout  time.Duration
+		addr     netip.Addr
+		tags     []string
+		nested   nested
+		optional any
+	}
+
+	Run(Test("text values", func() {
+		rec := testcase.Record{Values: []testcase.Value{
+			testcase.TextValue("input", "hello"),
+			testcase.TextValue("count", "42"),
+			testcase.TextValue("enabled", "true"),
+			testcase.TextValue("ratio", "0.5"),
+			testcase.TextValue("size", "8"),
+			testcase.TextValue("timeout", "1.5s"),
+			testcase.TextValue("addr", "127.0.0.1"),
+			testcase.TextValue("tags", `["a","b"]`),
+			testcase.TextValue("nested", `{"a":1}`),
+			testcase.TextValue("optional", "any"),
+		}}
+
+		result, err := rec.Apply(tc{})
+
+		Expect(err).IsNil()
+		Expect(result).To(DeepEqual(any(tc{
+			Input:    "hello",
+			count:    42,
+			enabled:  true,
+			ratio:    0.5,
+			size:     8,
+			timeout:  1500 * time.Millisecond,
+			addr:     netip.MustParseAddr("127.0.0.1"),
+			tags:     []string{"a", "b"},
+			nested:   nested{A: 1},
+			optional: "any",
+		})))
+	}))
+
+	Run(Test("empty text values", func() {
+		rec := testcase.Record{Values: []testcase.Value{
+			testcase.TextValue("input", ""),
+			testcase.TextValue("count", ""),
+			testcase.TextValue("tags", ""),
+		}}
+
+		result, err := rec.Apply(tc{Input: "input", count: 1, tags: []string{"a"}})
+
+		Expect(err).IsNil()
+		Expect(result).To(DeepEqual(any(tc{})))
+	}))
+
+	Run(Test("json values", func() {
+		rec := testcase.Record{Values: []testcase.Value{
+			testcase.JSONValue("input", []byte(`"hello"`)),
+			testcase.JSONValue("count", []byte(`42`)),
+			testcase.JSONValue("tags", []byte(`["a","b"]`)),
+		}}
+
+		result, err := rec.Apply(tc{})
+
+		Expect(err).IsNil()
+		Expect(result).To(DeepEqual(any(tc{Input: "hello", count: 42, tags: []string{"a", "b"}})))
+	}))
+
+	Run(Test("name with no field", func() {
+		rec := testcase.Record{Name: "case", Values: []testcase.Value{
+			testcase.TextValue("name", "case"),
+		}}
+
+		_, err := rec.Apply(tc{})
+
+		Expect(err).IsNil()
+	}))
+
+	Run(Test("no field", func() {
+		rec := testcase.Record{Values: []testcase.Value{testcase.TextValue("output", "x")}}
+
+		_, err := rec.Apply(tc{})
+
+		Expect(err).Is(testcase.ErrNoField)
+	}))
+
+	Run(Test("incompatible value", func() {
+		rec := testcase.Record{Values: []testcase.Value{testcase.TextValue("count", "many")}}
+
+		_, err := rec.Apply(tc{})
+
+		Expect(err).Is(testcase.ErrIncompatible)
+	}))
+
+	Run(Test("not a struct", func() {
+		rec := testcase.Record{}
+
+		_, err := rec.Apply(1)
+
+		Expect(err).Is(testcase.ErrNotAStruct)
+	}))
+}
+
+func TestParseJSON(t *testing.T) {
+	With(t)
+
+	names := func(recs []testcase.Record) []string {
+		result := make([]string, len(recs))
+		for i, r := range recs {
+			result[i] = r.Name
+		}
+		return result
+	}
+
+	Run(Test("array", func() {
+		result, err := testcase.ParseJSON([]byte(`[{"name":"first","a":1},{"a":2}]`))
+
+		Expect(err).IsNil()
+		Expect(names(result)).To(EqualSlice([]string{"first", ""}))
+		Expect(len(result[0].Values)).To(Equal(2))
+	}))
+
+	Run(Test("object", func() {
+		result, err := testcase.ParseJSON([]byte(`{"second":{"a":2},"first":{"a":1}}`))
+
+		Expect(err).IsNil()
+		Expect(names(result)).To(EqualSlice([]string{"second", "first"}))
+	}))
+
+	Run(Test("not an array or object", func() {
+		_, err := testcase.ParseJSON([]byte(`42`))
+
+		Expect(err).Is(testcase.ErrInvalidData)
+	}))
+
+	Run(Test("record is not an object", func() {
+		_, err := testcase.ParseJSON([]byte(`[42]`))
+
+		Expect(err).Is(testcase.ErrInvalidData)
+		Expect(err.Error()).To(ContainString("record 1"))
+	}))
+
+	Run(Test("malformed", func() {
+		_, err := testcase.ParseJSON([]byte(`[{"a":}]`))
+
+		Expect(err).Is(testcase.ErrInvalidData)
+	}))
+}
+
+func TestParseCSV(t *testing.T) {
+	With(t)
+
+	Run(Test("records", func() {
+		result, err := testcase.ParseCSV(strings.NewReader("# comment\nname, a\nfirst,1\n,2\n"))
+
+		Expect(err).IsNil()
+		Expect(len(result)).To(Equal(2))
+		Expect(result[0].Name).To(Equal("first"))
+		Expect(result[0].Values[1].Field).To(Equal("a"))
+		Expect(result[1].Name).To(Equal(""))
+	}))
+
+	Run(Test("no header", func() {
+		_, err := testcase.ParseCSV(strings.NewReader(""))
+
+		Expect(err).Is(testcase.ErrInvalidData)
+	}))
+
+	Run(Test("wrong number of fields", func() {
+		_, err := testcase.ParseCSV(strings.NewReader("a,b\n1\n"))
+
+		Expect(err).Is(testcase.ErrInvalidData)
+		Expect(err.Error()).To(ContainString("record 1"))
+	}))
+}
+
+func TestParseArchive(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		input  string
+		output string
+	}
+
+	rec := testcase.ParseArchive("case", []byte("comment\n-- input --\nline 1\nline 2\n-- output --\n\n"))
+
+	result, err := rec.Apply(tc{})
+
+	Expect(err).IsNil()
+	Expect(rec.Name).To(Equal("case"))
+	Expect(result).To(Equal(any(tc{input: "line 1\nline 2"})))
+}
+
+func TestCasesFrom(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		input  string
+		result int
+	}
+
+	type run struct {
+		name  string
+		input string
+	}
+
+	wordcount := func(s string) int { return len(strings.Fields(s)) }
+
+	Run(Test("json", func() {
+		Run(Testcases(
+			ForEach(func(tc tc) {
+				Expect(wordcount(tc.input)).To(Equal(tc.result))
+			}),
+			CasesFromJSON[tc]("testdata/cases.json"),
+		))
+	}))
+
+	Run(Test("csv", func() {
+		Run(Testcases(
+			ForEach(func(tc tc) {
+				Expect(wordcount(tc.input)).To(Equal(tc.result))
+			}),
+			CasesFromCSV[tc]("testdata/cases.csv"),
+		))
+	}))
+
+	Run(Test("dir", func() {
+		names := []string{}
+
+		Run(Testcases(
+			For(func(name string, tc tc) {
+				names = append(names, name)
+				Expect(wordcount(tc.input)).To(Equal(tc.result))
+			}),
+			CasesFromDir[tc]("testdata/cases"),
+		))
+
+		Expect(names).To(EqualSlice([]string{"empty-input", "multiple-lines", "single-word"}))
+	}))
+
+	Run(Test("file not found", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEach(func(tc tc) {}),
+				CasesFromJSON[tc]("testdata/missing.json"),
+			))
+		})
+
+		result.ExpectInvalid("CasesFromJSON(): open testdata/missing.json: no such file or directory")
+	}))
+
+	Run(Test("no field", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEach(func(tc run) {}),
+				CasesFromCSV[run]("testdata/cases.csv"),
+			))
+		})
+
+		result.ExpectInvalid("CasesFromCSV(): testdata/cases.csv: test case 1: test case has no field: result")
+	}))
+}
@@ -0,0 +1,25 @@
+package testcase
+
+import (
+	"errors"
+)
+
+var (
+	// combination errors
+	ErrNoDimensions   = errors.New("at least one dimension is required")
+	ErrNoValues       = errors.New("dimension has no values")
+	ErrUnnamed        = errors.New("dimension has no name")
+	ErrDuplicateNames = errors.New("dimension names must be unique")
+
+	// environment errors
+	ErrInvalidShard   = errors.New("invalid shard")
+	ErrInvalidShuffle = errors.New("invalid shuffle")
+
+	// test data errors
+	ErrInvalidData = errors.New("invalid test data")
+
+	// field errors
+	ErrNotAStruct   = errors.New("test case is not a struct")
+	ErrNoField      = errors.New("test case has no field")
+	ErrIncompatible = errors.New("value is not compatible with field")
+)
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
+	"unsafe"
 )
 
 // FieldValue retrieves a field value of a specified type (reflect.Kind) from
@@ -83,3 +85,92 @@ func IsSkipping(tc any) bool {
 
 	return result
 }
+
+// TimeoutOf returns the duration of a time.Duration timeout/Timeout field of
+// the specified test case, if it is a struct with such a field, otherwise it
+// returns zero.
+func TimeoutOf(tc any) time.Duration {
+	extractFn := func(v reflect.Value) reflect.Value { return v }
+	fields := []string{"timeout", "Timeout"}
+
+	v, ok := FieldValue(tc, reflect.Int64, extractFn, fields...)
+	if !ok || v.Type() != reflect.TypeOf(time.Duration(0)) {
+		return 0
+	}
+
+	return time.Duration(v.Int())
+}
+
+// Outcome is the expected outcome of a test case, as specified by fields of
+// the test case with conventional names.
+type Outcome struct {
+	// Result is the value of any result/Result/want/Want field
+	Result    any
+	HasResult bool
+
+	// Err is the value of any err/Err/wantErr/WantErr field; this may be an
+	// error or a bool indicating whether any error is expected
+	Err    any
+	HasErr bool
+
+	// Panic is the value of any panic/Panic/wantPanic/WantPanic field with a
+	// non-zero value, other than a bool field; HasPanic is true if the field
+	// has a non-zero value, i.e. a panic is expected.  A bool field that is
+	// true expects any panic, with a nil Panic.
+	Panic    any
+	HasPanic bool
+}
+
+// ExpectedOutcome returns the expected outcome of the specified test case, as
+// specified by any fields of the test case with conventional names (see:
+// Outcome).  If the test case is not a struct (or pointer to a struct) a zero
+// Outcome is returned.
+func ExpectedOutcome(tc any) Outcome {
+	ref := reflect.Indirect(reflect.ValueOf(tc))
+	if ref.Kind() != reflect.Struct {
+		return Outcome{}
+	}
+
+	// the test case is copied to an addressable value so that the values
+	// of any unexported fields may be obtained
+	v := reflect.New(ref.Type()).Elem()
+	v.Set(ref)
+
+	result := Outcome{}
+	result.Result, result.HasResult = fieldInterface(v, "result", "Result", "want", "Want")
+	result.Err, result.HasErr = fieldInterface(v, "err", "Err", "wantErr", "WantErr")
+
+	// a panic field is tested for a zero value on the field itself, since a
+	// typed zero value (e.g. "" or false) is not nil as an interface
+	if f, ok := fieldValue(v, "panic", "Panic", "wantPanic", "WantPanic"); ok && !f.IsZero() {
+		result.HasPanic = true
+		if f.Kind() != reflect.Bool {
+			result.Panic = f.Interface()
+		}
+	}
+
+	return result
+}
+
+// fieldInterface returns the value of the first field of an addressable struct
+// with any of the specified names, as an interface, and true.  If the struct has
+// no field with any of the names, nil and false are returned.
+func fieldInterface(v reflect.Value, names ...string) (any, bool) {
+	if f, ok := fieldValue(v, names...); ok {
+		return f.Interface(), true
+	}
+	return nil, false
+}
+
+// fieldValue returns the first field of an addressable struct with any of the
+// specified names, and true.  The field is returned as a value that may be
+// read even if the field is unexported.  If the struct has no field with any
+// of the names, an invalid value and false are returned.
+func fieldValue(v reflect.Value, names ...string) (reflect.Value, bool) {
+	for _, n := range names {
+		if f := v.FieldByName(n); f.IsValid() {
+			return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem(), true //nolint: gosec // test cases commonly have unexported fields
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -2,6 +2,7 @@ package testcase_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/blugnu/test"
 	"github.com/blugnu/test/internal/testcase"
@@ -84,3 +85,93 @@ func Test_IsSkipping(t *testing.T) {
 		Case("with Skip", tc{inst: withSkip{true}, result: true}),
 	))
 }
+
+func Test_TimeoutOf(t *testing.T) {
+	With(t)
+
+	type withtimeout struct{ timeout time.Duration }
+	type withTimeout struct{ Timeout time.Duration }
+	type withInt64 struct{ timeout int64 }
+
+	type tc struct {
+		inst   any
+		result time.Duration
+	}
+	Run(Testcases(
+		ForEach(func(tc tc) {
+			result := testcase.TimeoutOf(tc.inst)
+			Expect(result).To(Equal(tc.result))
+		}),
+		Case("not a struct", tc{inst: time.Second}),
+		Case("no timeout field", tc{inst: struct{}{}}),
+		Case("with timeout", tc{inst: withtimeout{time.Second}, result: time.Second}),
+		Case("with Timeout", tc{inst: withTimeout{time.Second}, result: time.Second}),
+		Case("with timeout (ptr)", tc{inst: &withtimeout{time.Second}, result: time.Second}),
+		Case("with timeout (not a duration)", tc{inst: withInt64{1}}),
+	))
+}
+
+func Test_ExpectedOutcome(t *testing.T) {
+	With(t)
+
+	type withresult struct {
+		result int
+		err    error
+		panic  any
+	}
+	type withWant struct {
+		Want    string
+		WantErr bool
+	}
+
+	Run(Test("not a struct", func() {
+		result := testcase.ExpectedOutcome(42)
+
+		Expect(result).To(DeepEqual(testcase.Outcome{}))
+	}))
+
+	Run(Test("no outcome fields", func() {
+		result := testcase.ExpectedOutcome(struct{ input int }{})
+
+		Expect(result).To(DeepEqual(testcase.Outcome{}))
+	}))
+
+	Run(Test("result, err and panic", func() {
+		result := testcase.ExpectedOutcome(withresult{result: 42, panic: "boom"})
+
+		Expect(result).To(DeepEqual(testcase.Outcome{
+			Result:    42,
+			HasResult: true,
+			HasErr:    true,
+			Panic:     "boom",
+			HasPanic:  true,
+		}))
+	}))
+
+	Run(Test("typed panic fields with zero values", func() {
+		type withString struct{ panic string }
+		type withBool struct{ wantPanic bool }
+
+		Expect(testcase.ExpectedOutcome(withString{})).To(DeepEqual(testcase.Outcome{}))
+		Expect(testcase.ExpectedOutcome(withBool{})).To(DeepEqual(testcase.Outcome{}))
+	}))
+
+	Run(Test("bool panic field", func() {
+		type withBool struct{ WantPanic bool }
+
+		result := testcase.ExpectedOutcome(withBool{WantPanic: true})
+
+		Expect(result).To(DeepEqual(testcase.Outcome{HasPanic: true}))
+	}))
+
+	Run(Test("Want and WantErr (ptr)", func() {
+		result := testcase.ExpectedOutcome(&withWant{Want: "result", WantErr: true})
+
+		Expect(result).To(DeepEqual(testcase.Outcome{
+			Result:    "result",
+			HasResult: true,
+			Err:       true,
+			HasErr:    true,
+		}))
+	}))
+}
@@ -0,0 +1,219 @@
+package testcase
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// Dimension is a named list of values for a parameter of a test case, used
+// to generate combinations of test cases.
+type Dimension struct {
+	Name   string
+	Values []any
+}
+
+// Combination is a combination of values from a set of dimensions, held as
+// the index of the value from each dimension.
+type Combination []int
+
+// Name returns the name of a test case for a combination of values from a set
+// of dimensions, in the form "name=value/name=value".
+func (c Combination) Name(dims []Dimension) string {
+	s := make([]string, len(c))
+	for i, idx := range c {
+		s[i] = fmt.Sprintf("%s=%v", dims[i].Name, dims[i].Values[idx])
+	}
+	return strings.Join(s, "/")
+}
+
+// Apply returns a copy of a test case with the fields corresponding to each
+// dimension set to the value of that dimension in the combination.
+//
+// A field corresponds to a dimension if it has the same name as the dimension,
+// or if not, a name that is equal to the dimension name ignoring case.
+func (c Combination) Apply(tc any, dims []Dimension) (any, error) {
+	v := reflect.New(reflect.TypeOf(tc)).Elem()
+	v.Set(reflect.ValueOf(tc))
+
+	for i, idx := range c {
+		if err := setField(v, dims[i].Name, dims[i].Values[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return v.Interface(), nil
+}
+
+// setField sets the field of a struct corresponding to a named dimension.  The
+// struct must be addressable; unexported fields are supported.
+func setField(v reflect.Value, name string, value any) error {
+	f, err := field(v, name)
+	if err != nil {
+		return err
+	}
+
+	val := reflect.ValueOf(value)
+	switch {
+	case value == nil:
+		val = reflect.Zero(f.Type())
+	case val.Type().AssignableTo(f.Type()):
+		// NO-OP
+	case val.Type().ConvertibleTo(f.Type()):
+		val = val.Convert(f.Type())
+	default:
+		return fmt.Errorf("%w: %s: %T is not assignable to %s", ErrIncompatible, name, value, f.Type())
+	}
+
+	f.Set(val)
+	return nil
+}
+
+// field returns a settable reference to the field of a struct with a given
+// name or, if there is no such field, a name that is equal to the given name
+// ignoring case.  The struct must be addressable; unexported fields are
+// supported.
+func field(v reflect.Value, name string) (reflect.Value, error) {
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		f = v.FieldByNameFunc(func(s string) bool { return strings.EqualFold(s, name) })
+	}
+	if !f.IsValid() {
+		return f, fmt.Errorf("%w: %s", ErrNoField, name)
+	}
+
+	if !f.CanSet() {
+		f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem() //nolint: gosec // test cases commonly have unexported fields
+	}
+	return f, nil
+}
+
+// ValidateDimensions returns an error if a set of dimensions cannot be used to
+// generate test cases from a test case of a given type.
+func ValidateDimensions(tc any, dims []Dimension) error {
+	if len(dims) == 0 {
+		return ErrNoDimensions
+	}
+
+	if reflect.TypeOf(tc) == nil || reflect.TypeOf(tc).Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrNotAStruct, tc)
+	}
+
+	names := map[string]bool{}
+	for _, d := range dims {
+		switch {
+		case d.Name == "":
+			return ErrUnnamed
+		case len(d.Values) == 0:
+			return fmt.Errorf("%w: %s", ErrNoValues, d.Name)
+		case names[d.Name]:
+			return fmt.Errorf("%w: %s", ErrDuplicateNames, d.Name)
+		}
+		names[d.Name] = true
+	}
+	return nil
+}
+
+// AllCombinations returns the cross product of a set of dimensions; that is,
+// every combination of the values of the dimensions.  Combinations are ordered
+// with the values of the last dimension varying fastest.
+func AllCombinations(dims []Dimension) []Combination {
+	result := []Combination{{}}
+	for _, d := range dims {
+		next := make([]Combination, 0, len(result)*len(d.Values))
+		for _, c := range result {
+			for i := range d.Values {
+				next = append(next, append(append(Combination{}, c...), i))
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// PairwiseCombinations returns a set of combinations of the values of a set of
+// dimensions in which every pair of values from any two dimensions occurs in
+// at least one combination (all-pairs testing).  This is typically far fewer
+// combinations than the cross product of the dimensions.
+//
+// Combinations are generated by a deterministic greedy algorithm: each
+// combination starts with the first pair not yet covered, with the values of
+// the remaining dimensions chosen to cover the most pairs not yet covered.
+func PairwiseCombinations(dims []Dimension) []Combination {
+	// with fewer than 3 dimensions, every combination is required to cover all pairs
+	const minDims = 3
+	if len(dims) < minDims {
+		return AllCombinations(dims)
+	}
+
+	// a pair is value a of dimension i with value b of dimension j (i < j);
+	// uncovered holds the pairs not yet covered by any combination
+	type pair struct{ i, a, j, b int }
+	uncovered := map[pair]bool{}
+	pairs := []pair{} // uncovered pairs, in a deterministic order
+	for i := 0; i < len(dims); i++ {
+		for j := i + 1; j < len(dims); j++ {
+			for a := range dims[i].Values {
+				for b := range dims[j].Values {
+					p := pair{i, a, j, b}
+					uncovered[p] = true
+					pairs = append(pairs, p)
+				}
+			}
+		}
+	}
+
+	result := []Combination{}
+	for len(uncovered) > 0 {
+		// start with the first uncovered pair
+		var first pair
+		for _, p := range pairs {
+			if uncovered[p] {
+				first = p
+				break
+			}
+		}
+
+		c := make(Combination, len(dims))
+		fixed := make([]bool, len(dims))
+		c[first.i], fixed[first.i] = first.a, true
+		c[first.j], fixed[first.j] = first.b, true
+
+		// choose values for the remaining dimensions that cover the most
+		// uncovered pairs with the values already chosen
+		for k := range dims {
+			if fixed[k] {
+				continue
+			}
+
+			best, bestN := 0, -1
+			for v := range dims[k].Values {
+				n := 0
+				for m := range dims {
+					if !fixed[m] {
+						continue
+					}
+					p := pair{m, c[m], k, v}
+					if k < m {
+						p = pair{k, v, m, c[m]}
+					}
+					if uncovered[p] {
+						n++
+					}
+				}
+				if n > bestN {
+					best, bestN = v, n
+				}
+			}
+			c[k], fixed[k] = best, true
+		}
+
+		for i := 0; i < len(dims); i++ {
+			for j := i + 1; j < len(dims); j++ {
+				delete(uncovered, pair{i, c[i], j, c[j]})
+			}
+		}
+		result = append(result, c)
+	}
+	return result
+}
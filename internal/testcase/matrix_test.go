@@ -0,0 +1,265 @@
+package testcase_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/blugnu/test"
+	"github.com/blugnu/test/internal/testcase"
+)
+
+func TestCombination_Name(t *testing.T) {
+	With(t)
+
+	dims := []testcase.Dimension{
+		{Name: "region", Values: []any{"eu", "us"}},
+		{Name: "tier", Values: []any{"gold", "silver"}},
+	}
+
+	result := testcase.Combination{1, 0}.Name(dims)
+
+	Expect(result).To(Equal("region=us/tier=gold"))
+}
+
+func TestCombination_Apply(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		Region string
+		tier   string
+		count  int64
+		err    error
+	}
+
+	Run(Test("exported, unexported and case-insensitive fields", func() {
+		dims := []testcase.Dimension{
+			{Name: "region", Values: []any{"eu"}},
+			{Name: "tier", Values: []any{"gold"}},
+		}
+
+		result, err := testcase.Combination{0, 0}.Apply(tc{count: 1}, dims)
+
+		Expect(err).IsNil()
+		Expect(result).To(Equal(any(tc{Region: "eu", tier: "gold", count: 1})))
+	}))
+
+	Run(Test("convertible value", func() {
+		dims := []testcase.Dimension{{Name: "count", Values: []any{42}}}
+
+		result, err := testcase.Combination{0}.Apply(tc{}, dims)
+
+		Expect(err).IsNil()
+		Expect(result).To(Equal(any(tc{count: 42})))
+	}))
+
+	Run(Test("nil value", func() {
+		dims := []testcase.Dimension{{Name: "err", Values: []any{nil}}}
+
+		result, err := testcase.Combination{0}.Apply(tc{err: errors.New("error")}, dims)
+
+		Expect(err).IsNil()
+		Expect(result).To(Equal(any(tc{})))
+	}))
+
+	Run(Test("base case is not modified", func() {
+		base := tc{tier: "silver"}
+		dims := []testcase.Dimension{{Name: "tier", Values: []any{"gold"}}}
+
+		_, _ = testcase.Combination{0}.Apply(base, dims)
+
+		Expect(base.tier).To(Equal("silver"))
+	}))
+
+	Run(Test("no field", func() {
+		dims := []testcase.Dimension{{Name: "zone", Values: []any{"a"}}}
+
+		_, err := testcase.Combination{0}.Apply(tc{}, dims)
+
+		Expect(err).Is(testcase.ErrNoField)
+	}))
+
+	Run(Test("incompatible value", func() {
+		dims := []testcase.Dimension{{Name: "tier", Values: []any{[]int{1}}}}
+
+		_, err := testcase.Combination{0}.Apply(tc{}, dims)
+
+		Expect(err).Is(testcase.ErrIncompatible)
+	}))
+}
+
+func TestValidateDimensions(t *testing.T) {
+	With(t)
+
+	type base struct{ a, b int }
+
+	type tc struct {
+		base any
+		dims []testcase.Dimension
+		err  error
+	}
+	Run(Testcases(
+		ForEach(func(tc tc) {
+			err := testcase.ValidateDimensions(tc.base, tc.dims)
+			Expect(err).Is(tc.err)
+		}),
+		Case("valid", tc{base: base{}, dims: []testcase.Dimension{{Name: "a", Values: []any{1}}}}),
+		Case("no dimensions", tc{base: base{}, err: testcase.ErrNoDimensions}),
+		Case("not a struct", tc{base: 1, dims: []testcase.Dimension{{Name: "a", Values: []any{1}}}, err: testcase.ErrNotAStruct}),
+		Case("nil", tc{dims: []testcase.Dimension{{Name: "a", Values: []any{1}}}, err: testcase.ErrNotAStruct}),
+		Case("unnamed", tc{base: base{}, dims: []testcase.Dimension{{Values: []any{1}}}, err: testcase.ErrUnnamed}),
+		Case("no values", tc{base: base{}, dims: []testcase.Dimension{{Name: "a"}}, err: testcase.ErrNoValues}),
+		Case("duplicate names", tc{base: base{}, dims: []testcase.Dimension{
+			{Name: "a", Values: []any{1}},
+			{Name: "a", Values: []any{2}},
+		}, err: testcase.ErrDuplicateNames}),
+	))
+}
+
+func TestAllCombinations(t *testing.T) {
+	With(t)
+
+	dims := []testcase.Dimension{
+		{Name: "a", Values: []any{1, 2}},
+		{Name: "b", Values: []any{1, 2, 3}},
+	}
+
+	result := testcase.AllCombinations(dims)
+
+	Expect(result).To(DeepEqual([]testcase.Combination{
+		{0, 0}, {0, 1}, {0, 2},
+		{1, 0}, {1, 1}, {1, 2},
+	}))
+}
+
+func TestPairwiseCombinations(t *testing.T) {
+	With(t)
+
+	Run(Test("fewer than 3 dimensions", func() {
+		dims := []testcase.Dimension{
+			{Name: "a", Values: []any{1, 2}},
+			{Name: "b", Values: []any{1, 2, 3}},
+		}
+
+		result := testcase.PairwiseCombinations(dims)
+
+		Expect(result).To(DeepEqual(testcase.AllCombinations(dims)))
+	}))
+
+	Run(Test("all pairs are covered", func() {
+		dims := []testcase.Dimension{
+			{Name: "a", Values: []any{1, 2, 3}},
+			{Name: "b", Values: []any{1, 2, 3}},
+			{Name: "c", Values: []any{1, 2, 3}},
+			{Name: "d", Values: []any{1, 2}},
+		}
+
+		result := testcase.PairwiseCombinations(dims)
+
+		type pair struct{ i, a, j, b int }
+		covered := map[pair]bool{}
+		for _, c := range result {
+			for i := 0; i < len(c); i++ {
+				for j := i + 1; j < len(c); j++ {
+					covered[pair{i, c[i], j, c[j]}] = true
+				}
+			}
+		}
+
+		Expect(len(covered)).To(Equal(3*3 + 3*3 + 3*2 + 3*3 + 3*2 + 3*2))
+		Expect(len(result)).To(BeLessThan(len(testcase.AllCombinations(dims))))
+	}))
+
+	Run(Test("deterministic", func() {
+		dims := []testcase.Dimension{
+			{Name: "a", Values: []any{1, 2, 3}},
+			{Name: "b", Values: []any{1, 2}},
+			{Name: "c", Values: []any{1, 2, 3, 4}},
+		}
+
+		result := testcase.PairwiseCombinations(dims)
+
+		Expect(result).To(DeepEqual(testcase.PairwiseCombinations(dims)))
+	}))
+}
+
+func TestMatrix(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		region string
+		tier   string
+	}
+
+	Run(Test("generates a case for each combination", func() {
+		mu := sync.Mutex{}
+		names := []string{}
+
+		Run(Testcases(
+			ForEach(func(tc tc) {
+				mu.Lock()
+				defer mu.Unlock()
+				name := T().Name()
+				names = append(names, name[strings.Index(name, "/")+1:])
+				Expect(name).To(ContainString("region=" + tc.region + "/tier=" + tc.tier))
+			}),
+			Matrix(tc{},
+				Dim("region", "eu", "us"),
+				Dim("tier", "gold", "silver"),
+			),
+		))
+
+		Expect(names).To(EqualSlice([]string{
+			"generates_a_case_for_each_combination/region=eu/tier=gold",
+			"generates_a_case_for_each_combination/region=eu/tier=silver",
+			"generates_a_case_for_each_combination/region=us/tier=gold",
+			"generates_a_case_for_each_combination/region=us/tier=silver",
+		}))
+	}))
+
+	Run(Test("invalid dimension", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEach(func(tc tc) {}),
+				Matrix(tc{}, Dim("zone", "a")),
+			))
+		})
+
+		result.ExpectInvalid("Matrix(): test case has no field: zone")
+	}))
+}
+
+func TestPairwise(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		a, b, c bool
+	}
+
+	Run(Test("covers all pairs", func() {
+		n := 0
+
+		Run(Testcases(
+			ForEach(func(tc tc) { n++ }),
+			Pairwise(tc{},
+				Dim("a", true, false),
+				Dim("b", true, false),
+				Dim("c", true, false),
+			),
+		))
+
+		Expect(n).To(Equal(4))
+	}))
+
+	Run(Test("no dimensions", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEach(func(tc tc) {}),
+				Pairwise(tc{}),
+			))
+		})
+
+		result.ExpectInvalid("Pairwise(): at least one dimension is required")
+	}))
+}
@@ -2,7 +2,10 @@ package testcase
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/blugnu/test/internal/testframe"
 	"github.com/blugnu/test/test"
@@ -48,6 +51,27 @@ type Runner[T any] struct {
 	TestExecutor[T]
 
 	CaseControllers []Controller[T]
+
+	// group is the path of names of the (nested) group to which test cases are
+	// currently being added
+	group []string
+
+	// timeout is the time allowed for each test case to complete, unless the
+	// test case specifies a timeout of its own; if zero, there is no timeout
+	timeout time.Duration
+
+	// maxParallel limits the number of parallel test cases that run at once;
+	// if zero, there is no limit.  When the test cases are run, sem is a
+	// semaphore with a capacity of maxParallel (if set)
+	maxParallel int
+	sem         chan struct{}
+
+	// hooks are functions called before and after the test cases are run
+	// (and before and after each individual test case)
+	beforeAll  []func()
+	afterAll   []func()
+	beforeEach []func(string, *T)
+	afterEach  []func(string, *T)
 }
 
 type Registration[T any] func(*Runner[T], Flags)
@@ -97,10 +121,82 @@ func (tcr *Runner[T]) AddCase(name string, tc T, flags ...Flags) {
 	ctrl.debug = ctrl.debug || (f&Debug != 0)
 	ctrl.skip = (ctrl.skip && (f&Debug == 0)) || (f&Skip != 0)
 	ctrl.parallel = ctrl.parallel || (f&Parallel != 0)
+	ctrl.group = slices.Clone(tcr.group)
 
 	tcr.CaseControllers[idx] = ctrl // update the controller in the list
 }
 
+// Group adds test cases to a named group by calling a function which adds the
+// test cases to the runner.  When run, the test cases in a group are run as
+// subtests of a subtest named for the group.
+//
+// Groups may be nested; test cases added to a group by the function are added
+// to a group nested within any group to which test cases are already being
+// added.  Test cases added to groups with the same name (in the same parent
+// group) are run in a single group.
+func (tcr *Runner[T]) Group(name string, fn func()) {
+	tcr.group = append(tcr.group, name)
+	defer func() { tcr.group = tcr.group[:len(tcr.group)-1] }()
+
+	fn()
+}
+
+// SetTimeout sets the time allowed for each test case to complete.  A test
+// case with a time.Duration timeout/Timeout field with a non-zero value is
+// instead allowed the time specified by that field.  A timeout of zero means
+// that test cases are allowed any time to complete (the default).
+//
+// A test case that does not complete in the time allowed fails, reporting
+// the stack of the goroutine running the test case; any remaining test cases
+// are then run.  See: runWithTimeout
+func (tcr *Runner[T]) SetTimeout(d time.Duration) {
+	tcr.timeout = d
+}
+
+// SetMaxParallel sets the maximum number of parallel test cases that run at
+// once, independently of the -parallel flag of go test.  A limit of zero means
+// that there is no limit (the default).
+func (tcr *Runner[T]) SetMaxParallel(n int) {
+	tcr.maxParallel = n
+}
+
+// BeforeAll adds a function to be called before any test cases are run.  The
+// function is called in the test frame of the runner.
+func (tcr *Runner[T]) BeforeAll(fn func()) {
+	tcr.beforeAll = append(tcr.beforeAll, fn)
+}
+
+// AfterAll adds a function to be called after all test cases have been run.
+// The function is called in the test frame of the runner.
+//
+// If any test cases are run in parallel, the function is called once those
+// test cases have completed, when the test of the runner is cleaned up.
+func (tcr *Runner[T]) AfterAll(fn func()) {
+	tcr.afterAll = append(tcr.afterAll, fn)
+}
+
+// BeforeEach adds a function to be called before each test case is run.  The
+// function is called in the subtest frame of the test case, with the name of
+// the test case and a pointer to (a copy of) the test case data, which may be
+// modified by the function before it is passed to the test executor.
+//
+// If the test fails (or panics) in a BeforeEach function, the test case is not
+// run and the test fails with a report identifying the failure as a failure in
+// setup.
+func (tcr *Runner[T]) BeforeEach(fn func(string, *T)) {
+	tcr.beforeEach = append(tcr.beforeEach, fn)
+}
+
+// AfterEach adds a function to be called after each test case is run.  The
+// function is called in the subtest frame of the test case, with the name of
+// the test case and a pointer to the test case data.
+//
+// AfterEach functions are called even if the test case fails, panics or is not
+// run due to a failure in a BeforeEach function.
+func (tcr *Runner[T]) AfterEach(fn func(string, *T)) {
+	tcr.afterEach = append(tcr.afterEach, fn)
+}
+
 // Run runs the test cases in the runner. Each test case is run as a subtest
 // in the current test frame. The test case name is used to identify the test
 // case in the test output, and any Before/After scaffolding functions are
@@ -114,64 +210,294 @@ func (tcr Runner[T]) Run() {
 		return
 	}
 
-	// initially assume that all test cases are runnable
-	runnable := tcr.CaseControllers
-
-	// if there are any debug test cases these are the only ones that will be run
+	// if there are any debug test cases these are the only ones that will be
+	// run; debug test cases are selected from all test cases before sharding,
+	// so that other shards do not run all of their test cases
 	debugging := tcr.getDebugCases()
+
+	// only the test cases in the shard being run (if any) are then considered
+	cases, err := inShard(tcr.CaseControllers)
+	if err != nil {
+		test.Invalid(err.Error())
+		return
+	}
+
+	// initially assume that all test cases in the shard are runnable
+	runnable := cases
 	if len(debugging) > 0 {
+		debugging, _ = inShard(debugging)
 		runnable = debugging
 	}
 
-	nSkipped := 0
-	for _, tc := range runnable {
-		name := tc.name
-		tcr.TestingT.Run(name, func(t *testing.T) {
-			testframe.Push(t)
+	// a shard with no test cases to run is not a failure; a small table of
+	// test cases split across many shards will leave some shards empty
+	if len(runnable) == 0 {
+		t.Logf("no test cases to run in shard %s=%s", ShardEnv, os.Getenv(ShardEnv))
+		return
+	}
+	tcr.CaseControllers = cases
+
+	seed, shuffle, err := ParseShuffle(os.Getenv(ShuffleEnv))
+	if err != nil {
+		test.Invalid(err.Error())
+		return
+	}
+
+	for _, fn := range tcr.beforeAll {
+		fn()
+	}
+
+	if tcr.maxParallel > 0 {
+		tcr.sem = make(chan struct{}, tcr.maxParallel)
+	}
+
+	// when shuffled, the seed is logged if the test fails so that the
+	// order of the test cases may be repeated
+	if shuffle {
+		runnable = shuffled(runnable, seed)
+		t.Cleanup(func() {
 			t.Helper()
+			if t.Failed() {
+				t.Logf("test cases were run in random order: %s=%d", ShuffleEnv, seed)
+			}
+		})
+	}
+
+	parallel := tcr.runCases(t, runnable, 0)
+
+	// parallel test cases are not run until the test of the runner has
+	// completed, so any AfterAll functions are then called when the test
+	// is cleaned up
+	if parallel && len(tcr.afterAll) > 0 {
+		t.Cleanup(func() {
+			testframe.Push(t)
+			defer testframe.Pop()
+
+			tcr.runAfterAll()
+		})
+	} else {
+		tcr.runAfterAll()
+	}
+
+	tcr.doWarnings(len(tcr.CaseControllers), len(debugging), countSkipped(runnable))
+}
+
+// runCases runs test cases in a given test, at a given depth of nesting in
+// groups.  Test cases in a group nested at that depth are run in a subtest
+// for the group, in the order in which the first test case in each group was
+// added.
+//
+// Returns true if any test case run directly in the test is run in parallel.
+func (tcr Runner[T]) runCases(t TestingT, cases []Controller[T], depth int) bool {
+	t.Helper()
 
-			if tc.skip {
-				nSkipped++
-				t.SkipNow()
+	parallel := false
+	grouped := map[string]bool{}
+	for _, tc := range cases {
+		if len(tc.group) > depth {
+			name := tc.group[depth]
+			if !grouped[name] {
+				grouped[name] = true
+				tcr.runGroup(t, tc.group[:depth+1], cases)
 			}
+			continue
+		}
+
+		parallel = parallel || (tc.parallel && !tc.skip)
+		tcr.runCase(t, tc)
+	}
+	return parallel
+}
 
-			if tc.parallel {
-				t.Parallel()
+// runGroup runs the test cases in a group, identified by the path of names of
+// the group, in a subtest named for the group.  A summary of any test cases in
+// the group that were skipped or not debugged is logged in the subtest of the
+// group; warnings are reported (once) for the runner as a whole.
+func (tcr Runner[T]) runGroup(t TestingT, path []string, cases []Controller[T]) {
+	t.Helper()
+
+	runnable := []Controller[T]{}
+	for _, tc := range cases {
+		if tc.inGroup(path) {
+			runnable = append(runnable, tc)
+		}
+	}
+
+	all, debugging := 0, 0
+	for _, tc := range tcr.CaseControllers {
+		if tc.inGroup(path) {
+			all++
+			if tc.debug {
+				debugging++
 			}
+		}
+	}
 
-			tc := tc.data // copy the test case data
-			tcr.TestExecutor.Execute(name, tc)
-		})
+	t.Run(path[len(path)-1], func(t *testing.T) {
+		testframe.Push(t)
+		t.Helper()
+
+		tcr.runCases(t, runnable, len(path))
+		for _, s := range summarise(all, debugging, countSkipped(runnable)) {
+			t.Log(s)
+		}
+	})
+}
+
+// runCase runs a test case in a subtest named for the test case
+func (tcr Runner[T]) runCase(t TestingT, tc Controller[T]) {
+	t.Helper()
+
+	name := tc.name
+	t.Run(name, func(t *testing.T) {
+		testframe.Push(t)
+		t.Helper()
+
+		if tc.skip {
+			t.SkipNow()
+		}
+
+		if tc.parallel {
+			t.Parallel()
+
+			// the semaphore is acquired only once the test case is parallel;
+			// parallel test cases are not resumed until the test of the runner
+			// has completed, so could not otherwise release the semaphore.
+			//
+			// The semaphore is released when the subtest ends, including when
+			// the test case times out; the goroutine of an abandoned test case
+			// then no longer counts against the limit
+			if tcr.sem != nil {
+				tcr.sem <- struct{}{}
+				defer func() { <-tcr.sem }()
+			}
+		}
+
+		timeout := tcr.timeout
+		if tc.timeout > 0 {
+			timeout = tc.timeout
+		}
+
+		tc := tc.data // copy the test case data
+
+		if timeout > 0 {
+			tcr.runWithTimeout(t, name, &tc, timeout)
+			return
+		}
+		tcr.execute(t, name, &tc)
+	})
+}
+
+// execute calls any BeforeEach functions for a test case, executes the test
+// case and then calls any AfterEach functions
+func (tcr Runner[T]) execute(t caseT, name string, tc *T) {
+	t.Helper()
+
+	defer tcr.teardown(name, tc)
+	tcr.setup(t, name, tc)
+
+	tcr.TestExecutor.Execute(name, *tc)
+}
+
+// countSkipped returns the number of test cases to be skipped
+func countSkipped[T any](cases []Controller[T]) int {
+	n := 0
+	for _, tc := range cases {
+		if tc.skip {
+			n++
+		}
+	}
+	return n
+}
+
+// runAfterAll calls any AfterAll functions
+func (tcr Runner[T]) runAfterAll() {
+	for _, fn := range tcr.afterAll {
+		fn()
+	}
+}
+
+// setup calls any BeforeEach functions for a test case.  If the test fails
+// or panics in a BeforeEach function, the failure is reported as a failure
+// in setup and the test case is not run.
+func (tcr Runner[T]) setup(t caseT, name string, tc *T) {
+	t.Helper()
+
+	completed := false
+	defer func() {
+		t.Helper()
+
+		r := recover()
+		if completed && !t.Failed() {
+			return
+		}
+
+		const msg = "<== SETUP FAILED: a BeforeEach function failed; the test case was not run"
+		if r != nil {
+			t.Errorf("%s\nrecovered: %v", msg, r)
+		} else {
+			t.Errorf(msg)
+		}
+
+		// if the setup did not complete and did not panic, the test is already
+		// exiting (e.g. due to a call to FailNow) so must not be exited again
+		if completed || r != nil {
+			t.FailNow()
+		}
+	}()
+
+	for _, fn := range tcr.beforeEach {
+		fn(name, tc)
 	}
+	completed = true
+}
 
-	tcr.doWarnings(len(debugging), nSkipped)
+// teardown calls any AfterEach functions for a test case
+func (tcr Runner[T]) teardown(name string, tc *T) {
+	for _, fn := range tcr.afterEach {
+		fn(name, tc)
+	}
 }
 
-// doWarnings reports any warnings that should be issued after running the
-// test cases.
-func (tcr Runner[T]) doWarnings(nDebugged, nSkipped int) {
+// doWarnings reports any warnings that should be issued after running some
+// number of test cases, of which some number were debugged or skipped.
+func (tcr Runner[T]) doWarnings(nCases, nDebugged, nSkipped int) {
 	tcr.Helper()
 
+	for _, s := range summarise(nCases, nDebugged, nSkipped) {
+		test.Warning(s)
+	}
+}
+
+// summarise returns a summary of any test cases that were not evaluated, of
+// some number of test cases of which some number were debugged or skipped;
+// the summary is empty if all test cases were evaluated.
+func summarise(nCases, nDebugged, nSkipped int) []string {
+	result := []string{}
+
 	switch nDebugged {
 	case 0:
 		// no debug cases, nothing to report
-	case len(tcr.CaseControllers):
+	case nCases:
 		// a bit odd, but this means that all cases were evaluated
 		// so nothing to report
 	default:
-		test.Warning(fmt.Sprintf("only %d of %d cases were evaluated (debug mode)", nDebugged, len(tcr.CaseControllers)))
+		result = append(result, fmt.Sprintf("only %d of %d cases were evaluated (debug mode)", nDebugged, nCases))
 	}
 
 	switch nSkipped {
 	case 0:
 		// no cases were skipped, nothing to report
-	case len(tcr.CaseControllers):
-		test.Warning("all cases were skipped")
+	case nCases:
+		result = append(result, "all cases were skipped")
 	default:
-		test.Warning(fmt.Sprintf("%d of %d cases were skipped", nSkipped, len(tcr.CaseControllers)))
+		result = append(result, fmt.Sprintf("%d of %d cases were skipped", nSkipped, nCases))
 	}
+
+	return result
 }
 
+// getDebugCases returns the test cases of the runner that are to be debugged
 func (tcr Runner[T]) getDebugCases() []Controller[T] {
 	result := make([]Controller[T], 0, len(tcr.CaseControllers))
 
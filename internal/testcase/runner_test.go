@@ -1,11 +1,14 @@
 package testcase_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	. "github.com/blugnu/test"
 	"github.com/blugnu/test/internal/testcase"
+	"github.com/blugnu/test/opt"
 )
 
 func TestNewRunner(t *testing.T) {
@@ -136,3 +139,491 @@ func TestRunner_Run(t *testing.T) {
 		result.Expect(TestPassed)
 	}))
 }
+
+func TestRunner_Hooks(t *testing.T) {
+	With(t)
+
+	Run(Test("order of execution", func() {
+		calls := []string{}
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc int) {
+					calls = append(calls, fmt.Sprintf("exec %s: %d", name, tc))
+				}),
+				BeforeAll[int](func() { calls = append(calls, "before all") }),
+				AfterAll[int](func() { calls = append(calls, "after all") }),
+				BeforeEach(func(name string, tc *int) {
+					calls = append(calls, "before "+name)
+					*tc *= 10
+				}),
+				AfterEach(func(name string, tc *int) {
+					calls = append(calls, fmt.Sprintf("after %s: %d", name, *tc))
+				}),
+				Case("a", 1),
+				Case("b", 2),
+			))
+		})
+
+		result.Expect(TestPassed)
+		Expect(calls).To(EqualSlice([]string{
+			"before all",
+			"before a",
+			"exec a: 10",
+			"after a: 10",
+			"before b",
+			"exec b: 20",
+			"after b: 20",
+			"after all",
+		}))
+	}))
+
+	Run(Test("parallel cases", func() {
+		mu := sync.Mutex{}
+		calls := []string{}
+		record := func(s string) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, s)
+		}
+
+		result := TestHelper(func() {
+			Run(ParallelCases(
+				For(func(name string, tc int) {
+					time.Sleep(5 * time.Millisecond)
+					record("exec " + name)
+				}),
+				AfterAll[int](func() { record("after all") }),
+				Case("a", 1),
+				Case("b", 2),
+			))
+			record("end of test")
+		})
+
+		result.Expect(TestPassed)
+		Expect(calls).Should(HaveLen(4))
+		Expect(calls[0]).To(Equal("end of test"))
+		Expect(calls[3]).To(Equal("after all"))
+	}))
+
+	Run(Test("expectations in hooks apply to the subtest", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEach(func(tc int) {}),
+				AfterEach(func(name string, tc *int) {
+					Expect(*tc, "case "+name).To(Equal(1))
+				}),
+				Case("one", 1),
+				Case("two", 2),
+			))
+		})
+
+		result.Expect(
+			"case two:",
+			"expected 1, got 2",
+		)
+		Expect(result.FailedTests).To(EqualSlice([]string{
+			"TestRunner_Hooks/expectations_in_hooks_apply_to_the_subtest",
+			"TestRunner_Hooks/expectations_in_hooks_apply_to_the_subtest/two",
+		}))
+	}))
+
+	Run(Test("BeforeEach fails", func() {
+		calls := []string{}
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc int) { calls = append(calls, "exec "+name) }),
+				BeforeEach(func(name string, tc *int) {
+					Expect(*tc, "setup").To(Equal(1))
+				}),
+				AfterEach(func(name string, tc *int) { calls = append(calls, "after "+name) }),
+				Case("one", 1),
+				Case("two", 2),
+			))
+		})
+
+		result.Expect(
+			"setup:",
+			"expected 1, got 2",
+			"<== SETUP FAILED: a BeforeEach function failed; the test case was not run",
+		)
+		Expect(calls).To(EqualSlice([]string{
+			"exec one",
+			"after one",
+			"after two",
+		}))
+	}))
+
+	Run(Test("BeforeEach fails and exits", func() {
+		calls := []string{}
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc int) { calls = append(calls, "exec "+name) }),
+				BeforeEach(func(name string, tc *int) {
+					Expect(*tc, "setup").To(Equal(1), opt.IsRequired(true))
+				}),
+				Case("two", 2),
+			))
+		})
+
+		result.Expect(
+			"setup:",
+			"expected 1, got 2",
+			"<== SETUP FAILED: a BeforeEach function failed; the test case was not run",
+		)
+		Expect(calls).Should(BeEmptyOrNil())
+	}))
+
+	Run(Test("BeforeEach panics", func() {
+		calls := []string{}
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc int) { calls = append(calls, "exec "+name) }),
+				BeforeEach(func(name string, tc *int) { panic("no database") }),
+				Case("one", 1),
+			))
+		})
+
+		result.Expect(
+			"<== SETUP FAILED: a BeforeEach function failed; the test case was not run",
+			"recovered: no database",
+		)
+		Expect(calls).Should(BeEmptyOrNil())
+	}))
+
+	Run(Test("nil hooks", func() {
+		Run(HelperTests([]HelperScenario{
+			{Scenario: "BeforeAll",
+				Act:    func() { BeforeAll[int](nil) },
+				Assert: func(result *R) { result.ExpectInvalid("BeforeAll() function cannot be nil") },
+			},
+			{Scenario: "AfterAll",
+				Act:    func() { AfterAll[int](nil) },
+				Assert: func(result *R) { result.ExpectInvalid("AfterAll() function cannot be nil") },
+			},
+			{Scenario: "BeforeEach",
+				Act:    func() { BeforeEach[int](nil) },
+				Assert: func(result *R) { result.ExpectInvalid("BeforeEach() function cannot be nil") },
+			},
+			{Scenario: "AfterEach",
+				Act:    func() { AfterEach[int](nil) },
+				Assert: func(result *R) { result.ExpectInvalid("AfterEach() function cannot be nil") },
+			},
+		}...))
+	}))
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		block   bool
+		timeout time.Duration
+	}
+
+	// blocked test cases are released when the test completes, so that
+	// goroutines abandoned by timed out test cases are not leaked
+	release := make(chan struct{})
+	defer close(release)
+
+	blockUntilReleased := func() { <-release }
+
+	Run(Test("runner timeout", func() {
+		evals := []string{}
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(For(func(name string, tc tc) {
+				if tc.block {
+					blockUntilReleased()
+				}
+				evals = append(evals, name)
+			}))
+			runner.SetTimeout(10 * time.Millisecond)
+			runner.AddCase("blocked", tc{block: true})
+			runner.AddCase("completed", tc{})
+
+			runner.Run()
+		})
+
+		result.Expect(TestFailed, opt.IgnoreReport(true))
+		Expect(result.FailedTests).To(EqualSlice([]string{
+			"TestRunner_Timeout/runner_timeout",
+			"TestRunner_Timeout/runner_timeout/blocked",
+		}))
+		Expect(evals).To(EqualSlice([]string{"completed"}))
+	}))
+
+	Run(Test("test case timeout", func() {
+		elapsed := StopWatch(func() {
+			result := TestHelper(func() {
+				runner := testcase.NewRunner(ForEach(func(tc tc) {
+					if tc.block {
+						blockUntilReleased()
+					}
+				}))
+				runner.SetTimeout(time.Second)
+				runner.AddCase("blocked", tc{block: true, timeout: 10 * time.Millisecond})
+
+				runner.Run()
+			})
+
+			result.Expect(TestFailed, opt.IgnoreReport(true))
+		})
+
+		Expect(elapsed).To(BeLessThan(time.Second))
+	}))
+
+	Run(Test("completes within timeout", func() {
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc tc) {
+				Expect(IsParallel()).To(BeFalse())
+			}))
+			runner.SetTimeout(time.Second)
+			runner.AddCase("completed", tc{})
+
+			runner.Run()
+		})
+
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("timed out test case reports after deadline", func() {
+		// the abandoned test case is released once the test has timed out
+		// and completed; its reports must then be discarded rather than
+		// panicking with "Fail in goroutine after Test... has completed"
+		var (
+			late      = make(chan struct{})
+			reported  = make(chan struct{})
+			recovered any
+		)
+
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc tc) {
+				defer func() {
+					recovered = recover()
+					close(reported)
+				}()
+
+				<-late
+				Expect(true).To(BeFalse())
+				T().FailNow()
+			}))
+			runner.SetTimeout(10 * time.Millisecond)
+			runner.AddCase("abandoned", tc{})
+
+			runner.Run()
+		})
+		close(late)
+		<-reported
+
+		result.Expect(TestFailed, opt.IgnoreReport(true))
+		Expect(result.FailedTests).To(EqualSlice([]string{
+			"TestRunner_Timeout/timed_out_test_case_reports_after_deadline",
+			"TestRunner_Timeout/timed_out_test_case_reports_after_deadline/abandoned",
+		}))
+		Expect(recovered).To(BeNil())
+	}))
+
+	Run(Test("skipped in test case", func() {
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc tc) {
+				T().SkipNow()
+				Expect("unreachable").To(Equal("reached"))
+			}))
+			runner.SetTimeout(time.Second)
+			runner.AddCase("skipped", tc{})
+
+			runner.Run()
+		})
+
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("failure reported in test case", func() {
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc tc) {
+				Expect(true).To(BeFalse(), opt.IsRequired(true))
+				Expect("unreachable").To(Equal("reached"))
+			}))
+			runner.SetTimeout(time.Second)
+			runner.AddCase("failed", tc{})
+
+			runner.Run()
+		})
+
+		result.Expect(TestFailed, opt.IgnoreReport(true))
+		Expect(result.FailedTests).To(EqualSlice([]string{
+			"TestRunner_Timeout/failure_reported_in_test_case",
+			"TestRunner_Timeout/failure_reported_in_test_case/failed",
+		}))
+	}))
+}
+
+func TestRunner_Shard(t *testing.T) {
+	With(t)
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	run := func(shard string) []string {
+		T().Setenv(testcase.ShardEnv, shard)
+		T().Setenv(testcase.ShuffleEnv, "off")
+
+		evals := []string{}
+		Run(Test("shard "+shard, func() {
+			runner := testcase.NewRunner(For(func(name string, tc int) {
+				evals = append(evals, name)
+			}))
+			for i, name := range names {
+				runner.AddCase(name, i)
+			}
+			runner.Run()
+		}))
+		return evals
+	}
+
+	Run(Test("shards cover all cases", func() {
+		all := []string{}
+		for _, shard := range []string{"1/3", "2/3", "3/3"} {
+			evals := run(shard)
+			Expect(len(evals), shard).To(BeLessThan(len(names)))
+			all = append(all, evals...)
+		}
+
+		Expect(all).To(EqualSlice(names), opt.AnyOrder())
+	}))
+
+	Run(Test("shards are stable", func() {
+		Expect(run("2/3")).To(EqualSlice(run("2/3")))
+	}))
+
+	Run(Test("skipped cases in shard", func() {
+		T().Setenv(testcase.ShardEnv, "1/2")
+
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc int) {}))
+			for _, name := range names {
+				runner.AddCase(name, 0, testcase.Skip)
+			}
+			runner.Run()
+		})
+
+		result.ExpectWarning("all cases were skipped")
+	}))
+
+	Run(Test("debug cases across shards", func() {
+		for _, shard := range []string{"1/3", "2/3", "3/3"} {
+			T().Setenv(testcase.ShardEnv, shard)
+			s, _ := testcase.ParseShard(shard)
+
+			evals := []string{}
+			result := TestHelper(func() {
+				runner := testcase.NewRunner(For(func(name string, tc int) {
+					evals = append(evals, name)
+				}))
+				for i, name := range names {
+					flags := testcase.Flags(0)
+					if name == "c" {
+						flags = testcase.Debug
+					}
+					runner.AddCase(name, i, flags)
+				}
+				runner.Run()
+			})
+
+			if s.Includes("c") {
+				Expect(evals, shard).To(EqualSlice([]string{"c"}))
+				continue
+			}
+			Expect(evals, shard).Should(BeEmpty())
+			result.Expect(TestPassed)
+		}
+	}))
+
+	Run(Test("empty shard", func() {
+		shard := "1/2"
+		if s, _ := testcase.ParseShard(shard); s.Includes("a") {
+			shard = "2/2"
+		}
+		T().Setenv(testcase.ShardEnv, shard)
+
+		evals := 0
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc int) { evals++ }))
+			runner.AddCase("a", 1)
+			runner.Run()
+		})
+
+		Expect(evals).To(Equal(0))
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("invalid shard", func() {
+		T().Setenv(testcase.ShardEnv, "6/5")
+
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc int) {}))
+			runner.AddCase("a", 1)
+			runner.Run()
+		})
+
+		result.ExpectInvalid(`invalid shard: BLUGNU_SHARD="6/5": expected i/n, with 1 <= i <= n`)
+	}))
+}
+
+func TestRunner_Shuffle(t *testing.T) {
+	With(t)
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	run := func() []string {
+		evals := []string{}
+		Run(Test("shuffled", func() {
+			runner := testcase.NewRunner(For(func(name string, tc int) {
+				evals = append(evals, name)
+			}))
+			for i, name := range names {
+				runner.AddCase(name, i)
+			}
+			runner.Run()
+		}))
+		return evals
+	}
+
+	Run(Test("seeded order", func() {
+		T().Setenv(testcase.ShuffleEnv, "42")
+
+		first := run()
+
+		Expect(first).ToNot(EqualSlice(names))
+		Expect(first).To(EqualSlice(names), opt.AnyOrder())
+		Expect(run()).To(EqualSlice(first))
+	}))
+
+	Run(Test("seed reported on failure", func() {
+		T().Setenv(testcase.ShuffleEnv, "42")
+
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc int) {
+				Expect(tc).To(Equal(1))
+			}))
+			runner.AddCase("pass", 1)
+			runner.AddCase("fail", 2)
+			runner.Run()
+		})
+
+		result.Expect(
+			"expected 1, got 2",
+			"test cases were run in random order: BLUGNU_SHUFFLE=42",
+		)
+	}))
+
+	Run(Test("invalid shuffle", func() {
+		T().Setenv(testcase.ShuffleEnv, "random")
+
+		result := TestHelper(func() {
+			runner := testcase.NewRunner(ForEach(func(tc int) {}))
+			runner.AddCase("a", 1)
+			runner.Run()
+		})
+
+		result.ExpectInvalid(`invalid shuffle: BLUGNU_SHUFFLE="random": expected on, off or an integer seed`)
+	}))
+}
@@ -0,0 +1,107 @@
+package testcase
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ShardEnv is the environment variable identifying a shard of test cases
+	// to be run, in the form "i/n" where n is the number of shards and i is the
+	// 1-based index of the shard, e.g. "2/5"
+	ShardEnv = "BLUGNU_SHARD"
+
+	// ShuffleEnv is the environment variable enabling test cases to be run in
+	// random order; the value is either "on", to shuffle using a seed derived
+	// from the time, or an integer seed, e.g. to repeat a previous shuffle
+	ShuffleEnv = "BLUGNU_SHUFFLE"
+)
+
+// Shard identifies one of a number of shards among which test cases are
+// distributed.  Index is the 1-based index of the shard and Count the number
+// of shards.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard parses a shard in the form "i/n", where n is the number of shards
+// and i is the 1-based index of the shard.  An empty string is parsed as a
+// single shard (i.e. "1/1").
+func ParseShard(s string) (Shard, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return Shard{Index: 1, Count: 1}, nil
+	}
+
+	i, n, ok := strings.Cut(s, "/")
+	index, err1 := strconv.Atoi(strings.TrimSpace(i))
+	count, err2 := strconv.Atoi(strings.TrimSpace(n))
+	if !ok || err1 != nil || err2 != nil || count < 1 || index < 1 || index > count {
+		return Shard{}, fmt.Errorf("%w: %s=%q: expected i/n, with 1 <= i <= n", ErrInvalidShard, ShardEnv, s)
+	}
+
+	return Shard{Index: index, Count: count}, nil
+}
+
+// Includes returns true if a test case with a given name is in the shard.  Test
+// cases are distributed among shards by a stable hash of the name, so a test
+// case is always in the same shard of a given number of shards.
+func (s Shard) Includes(name string) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index-1 //nolint: gosec // the count of shards is positive
+}
+
+// ParseShuffle parses a shuffle setting, returning the seed to be used and
+// true if test cases are to be shuffled.  The setting is either "on", in which
+// case a seed is derived from the current time, an integer seed, or "off" (or
+// empty) if test cases are not to be shuffled.
+func ParseShuffle(s string) (int64, bool, error) {
+	switch s = strings.TrimSpace(s); s {
+	case "", "off":
+		return 0, false, nil
+	case "on":
+		return time.Now().UnixNano(), true, nil
+	}
+
+	seed, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("%w: %s=%q: expected on, off or an integer seed", ErrInvalidShuffle, ShuffleEnv, s)
+	}
+	return seed, true, nil
+}
+
+// inShard returns the test cases that are in the shard identified by the
+// ShardEnv environment variable.  Each test case is identified by its name,
+// qualified by the names of any groups containing it.
+func inShard[T any](cases []Controller[T]) ([]Controller[T], error) {
+	shard, err := ParseShard(os.Getenv(ShardEnv))
+	if err != nil || shard.Count == 1 {
+		return cases, err
+	}
+
+	result := make([]Controller[T], 0, len(cases))
+	for _, tc := range cases {
+		if shard.Includes(strings.Join(append(slices.Clip(tc.group), tc.name), "/")) {
+			result = append(result, tc)
+		}
+	}
+	return result, nil
+}
+
+// shuffled returns a copy of a set of test cases in an order randomised using
+// a given seed
+func shuffled[T any](cases []Controller[T], seed int64) []Controller[T] {
+	result := append([]Controller[T]{}, cases...)
+
+	rng := rand.New(rand.NewSource(seed)) //nolint: gosec // not used for security
+	rng.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+
+	return result
+}
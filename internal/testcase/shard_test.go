@@ -0,0 +1,81 @@
+package testcase_test
+
+import (
+	"testing"
+
+	. "github.com/blugnu/test"
+	"github.com/blugnu/test/internal/testcase"
+)
+
+func TestParseShard(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		input  string
+		result testcase.Shard
+		err    error
+	}
+	Run(Testcases(
+		ForEachResult(func(tc tc) (testcase.Shard, error) {
+			return testcase.ParseShard(tc.input)
+		}),
+		Case("empty", tc{input: "", result: testcase.Shard{Index: 1, Count: 1}}),
+		Case("valid", tc{input: "2/5", result: testcase.Shard{Index: 2, Count: 5}}),
+		Case("whitespace", tc{input: " 2 / 5 ", result: testcase.Shard{Index: 2, Count: 5}}),
+		Case("last", tc{input: "5/5", result: testcase.Shard{Index: 5, Count: 5}}),
+		Case("no separator", tc{input: "2", err: testcase.ErrInvalidShard}),
+		Case("not a number", tc{input: "a/5", err: testcase.ErrInvalidShard}),
+		Case("zero index", tc{input: "0/5", err: testcase.ErrInvalidShard}),
+		Case("index out of range", tc{input: "6/5", err: testcase.ErrInvalidShard}),
+		Case("zero count", tc{input: "0/0", err: testcase.ErrInvalidShard}),
+	))
+}
+
+func TestParseShuffle(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		input   string
+		shuffle bool
+		err     error
+	}
+	Run(Testcases(
+		ForEach(func(tc tc) {
+			_, shuffle, err := testcase.ParseShuffle(tc.input)
+			Expect(err).Is(tc.err)
+			Expect(shuffle).To(Equal(tc.shuffle))
+		}),
+		Case("empty", tc{input: ""}),
+		Case("off", tc{input: "off"}),
+		Case("on", tc{input: "on", shuffle: true}),
+		Case("seed", tc{input: "42", shuffle: true}),
+		Case("invalid", tc{input: "random", err: testcase.ErrInvalidShuffle}),
+	))
+
+	Run(Test("seed", func() {
+		seed, _, _ := testcase.ParseShuffle("42")
+
+		Expect(seed).To(Equal(int64(42)))
+	}))
+}
+
+func TestShard_Includes(t *testing.T) {
+	With(t)
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	counts := map[string]int{}
+	for i := 1; i <= 3; i++ {
+		shard := testcase.Shard{Index: i, Count: 3}
+		for _, name := range names {
+			if shard.Includes(name) {
+				counts[name]++
+			}
+		}
+	}
+
+	// every name is included in exactly one shard
+	for _, name := range names {
+		Expect(counts[name], name).To(Equal(1))
+	}
+}
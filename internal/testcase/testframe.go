@@ -9,8 +9,11 @@ import (
 // TestingT is an interface that describes the methods of a testing.T
 // instance that are used in this package.
 type TestingT interface {
+	Cleanup(func())
 	Errorf(string, ...any)
+	Failed() bool
 	Helper()
+	Logf(string, ...any)
 	Parallel()
 	Run(string, func(t *testing.T)) bool
 }
@@ -20,3 +23,14 @@ type TestingT interface {
 func GetT() TestingT {
 	return testframe.MustPeek[TestingT]()
 }
+
+// caseT is an interface that describes the methods of the TestingT of a
+// test case used to run the test case (and any BeforeEach and AfterEach
+// functions); it is satisfied by *testing.T and by the guardedT of a test
+// case run with a timeout.
+type caseT interface {
+	Errorf(string, ...any)
+	Failed() bool
+	FailNow()
+	Helper()
+}
@@ -0,0 +1,247 @@
+package testcase
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blugnu/test/internal/testframe"
+)
+
+// runWithTimeout executes a test case (with any BeforeEach and AfterEach
+// functions) in a separate goroutine, waiting no longer than a specified
+// duration for it to complete.
+//
+// The test case runs in a test frame holding a guardedT rather than the
+// *testing.T of the test case, so that calls to FailNow or SkipNow in the test
+// case end the goroutine running it and are then applied to the test.
+//
+// If the test case does not complete in time the test fails, reporting the
+// stack of the goroutine running the test case to identify where it is stuck.
+// The goroutine cannot be stopped and is abandoned; AfterEach functions are
+// not called unless and until the test case completes.  Any failures (or other
+// output) reported by the abandoned goroutine once the test has timed out are
+// discarded.
+//
+// A panic in the test case is recovered in the goroutine and re-raised in the
+// test.
+func (tcr Runner[T]) runWithTimeout(t *testing.T, name string, tc *T, d time.Duration) {
+	t.Helper()
+
+	var (
+		gt        = &guardedT{T: t}
+		done      = make(chan struct{})
+		id        = make(chan uintptr, 1)
+		completed bool
+		recovered any
+		stack     string
+	)
+
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				recovered, stack = r, string(stackDump(false))
+			}
+		}()
+
+		id <- testframe.GoroutineID()
+
+		testframe.Push(gt)
+		defer testframe.Pop()
+
+		tcr.execute(gt, name, tc)
+		completed = true
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		switch {
+		case recovered != nil:
+			t.Logf("test case panicked:\n%s", stack)
+			panic(recovered)
+		case completed:
+			// nothing to do
+		case gt.skipped():
+			t.SkipNow()
+		default:
+			// the goroutine exited without completing the test case, i.e.
+			// FailNow was called in the test case
+			t.FailNow()
+		}
+
+	case <-timer.C:
+		gt.expire()
+		t.Errorf("<== TIMEOUT: test case did not complete within %v\n\n%s", d, goroutineStack(<-id))
+		t.FailNow()
+	}
+}
+
+// guardedT is the TestingT of a test case run with a timeout.  It embeds the
+// *testing.T of the test case, guarding those methods that must not be called
+// once the test has completed: once the test case has timed out, calls to
+// these methods are discarded.
+//
+// FailNow and SkipNow (and Fatal, Fatalf, Skip and Skipf) end the goroutine
+// running the test case; the test is then failed or skipped by runWithTimeout.
+type guardedT struct {
+	*testing.T
+	mu      sync.Mutex
+	expired bool
+	skip    bool
+}
+
+// expire marks the test case as timed out; subsequent calls to guarded
+// methods are discarded
+func (g *guardedT) expire() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.expired = true
+}
+
+// isExpired returns true if the test case has timed out
+func (g *guardedT) isExpired() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.expired
+}
+
+// skipped returns true if SkipNow was called for the test case
+func (g *guardedT) skipped() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.skip
+}
+
+// guard calls a function unless the test case has timed out
+func (g *guardedT) guard(fn func()) {
+	g.T.Helper()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.expired {
+		fn()
+	}
+}
+
+func (g *guardedT) Cleanup(fn func()) { g.guard(func() { g.T.Cleanup(fn) }) }
+
+func (g *guardedT) Error(args ...any) {
+	g.T.Helper()
+	g.guard(func() { g.T.Helper(); g.T.Error(args...) })
+}
+
+func (g *guardedT) Errorf(s string, args ...any) {
+	g.T.Helper()
+	g.guard(func() { g.T.Helper(); g.T.Errorf(s, args...) })
+}
+
+func (g *guardedT) Fail() { g.guard(g.T.Fail) }
+
+func (g *guardedT) FailNow() {
+	g.Fail()
+	runtime.Goexit()
+}
+
+func (g *guardedT) Fatal(args ...any) {
+	g.T.Helper()
+	g.Error(args...)
+	g.FailNow()
+}
+
+func (g *guardedT) Fatalf(s string, args ...any) {
+	g.T.Helper()
+	g.Errorf(s, args...)
+	g.FailNow()
+}
+
+func (g *guardedT) Log(args ...any) {
+	g.T.Helper()
+	g.guard(func() { g.T.Helper(); g.T.Log(args...) })
+}
+
+func (g *guardedT) Logf(s string, args ...any) {
+	g.T.Helper()
+	g.guard(func() { g.T.Helper(); g.T.Logf(s, args...) })
+}
+
+// Parallel signals that the test case is to be run in parallel.  The guard is
+// not held while waiting to be resumed (which is not until the parent test has
+// completed), so that the test case may time out in the meantime.
+func (g *guardedT) Parallel() {
+	if !g.isExpired() {
+		g.T.Parallel()
+	}
+}
+
+// Run runs a subtest of the test case.  The guard is not held while the
+// subtest runs, so that a subtest that does not complete does not prevent
+// the test case from timing out.
+func (g *guardedT) Run(name string, fn func(*testing.T)) bool {
+	if g.isExpired() {
+		return false
+	}
+	return g.T.Run(name, fn)
+}
+
+func (g *guardedT) Setenv(name, value string) { g.guard(func() { g.T.Setenv(name, value) }) }
+
+func (g *guardedT) Skip(args ...any) {
+	g.T.Helper()
+	g.Log(args...)
+	g.SkipNow()
+}
+
+func (g *guardedT) Skipf(s string, args ...any) {
+	g.T.Helper()
+	g.Logf(s, args...)
+	g.SkipNow()
+}
+
+func (g *guardedT) SkipNow() {
+	g.mu.Lock()
+	g.skip = true
+	g.mu.Unlock()
+
+	runtime.Goexit()
+}
+
+// stackDump returns the stack of the calling goroutine or, if all is true,
+// of all goroutines
+func stackDump(all bool) []byte {
+	const bufsize = 65536
+
+	// the buffer is grown until it is large enough to hold the entire dump
+	buf := make([]byte, bufsize)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, len(buf)+bufsize)
+	}
+}
+
+// goroutineStack returns the stack of the goroutine with a specified id or,
+// if the goroutine cannot be identified, the stacks of all goroutines
+func goroutineStack(id uintptr) string {
+	dump := string(stackDump(true))
+
+	prefix := fmt.Sprintf("goroutine %d [", id)
+	for _, s := range strings.Split(dump, "\n\n") {
+		if strings.HasPrefix(s, prefix) {
+			return strings.TrimSpace(s)
+		}
+	}
+	return strings.TrimSpace(dump)
+}
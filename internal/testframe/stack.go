@@ -32,6 +32,11 @@ func goid() uintptr {
 	return id
 }
 
+// GoroutineID returns the id of the calling goroutine (see goid).
+func GoroutineID() uintptr {
+	return goid()
+}
+
 type testframe struct {
 	T any
 	// ref string
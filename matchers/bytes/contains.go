@@ -0,0 +1,104 @@
+package bytes
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/blugnu/test/opt"
+	"github.com/blugnu/test/test"
+)
+
+// toBytes returns a []byte with the same contents as a slice of some
+// type derived from byte
+func toBytes[T ~byte](s []T) []byte {
+	result := make([]byte, len(s))
+	for i, b := range s {
+		result[i] = byte(b)
+	}
+	return result
+}
+
+// ContainsMatcher is a matcher that tests whether a byte slice contains an
+// expected sequence of bytes.  Since any slice contains an empty sequence,
+// an empty (or nil) expected sequence fails the test as invalid.
+type ContainsMatcher[T ~byte] struct {
+	Expected []T
+}
+
+func (bm *ContainsMatcher[T]) Match(got []T, _ ...any) bool {
+	if len(bm.Expected) == 0 {
+		test.T().Helper()
+		test.Invalid("bytes.ContainsMatcher: no expected bytes specified")
+	}
+
+	return bytes.Contains(toBytes(got), toBytes(bm.Expected))
+}
+
+func (bm *ContainsMatcher[T]) OnTestFailure(got []T, opts ...any) []string {
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		offset := bytes.Index(toBytes(got), toBytes(bm.Expected))
+		result := []string{
+			"unexpected: []byte should not contain: " + hexBytes(bm.Expected),
+			"  found at offset: " + strconv.Itoa(offset),
+		}
+		return append(result, hexDump("got     : ", got)...)
+	}
+
+	result := []string{"expected: []byte containing: " + hexBytes(bm.Expected)}
+	return append(result, hexDump("got     : ", got)...)
+}
+
+// PrefixMatcher is a matcher that tests whether a byte slice begins with an
+// expected sequence of bytes.
+type PrefixMatcher[T ~byte] struct {
+	Expected []T
+}
+
+func (bm *PrefixMatcher[T]) Match(got []T, _ ...any) bool {
+	return bytes.HasPrefix(toBytes(got), toBytes(bm.Expected))
+}
+
+func (bm *PrefixMatcher[T]) OnTestFailure(got []T, opts ...any) []string {
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return append([]string{"unexpected: []byte should not have prefix: " + hexBytes(bm.Expected)},
+			hexDump("got     : ", got)...,
+		)
+	}
+
+	result := []string{"bytes prefix not matched:"}
+	if len(got) < len(bm.Expected) {
+		result = append(result, "  got "+strconv.Itoa(len(got))+" bytes, shorter than prefix of "+strconv.Itoa(len(bm.Expected)))
+	}
+
+	window := got[:min(len(got), len(bm.Expected))]
+	return append(result, hexDiff(bm.Expected, window, 0, opts...)...)
+}
+
+// SuffixMatcher is a matcher that tests whether a byte slice ends with an
+// expected sequence of bytes.
+type SuffixMatcher[T ~byte] struct {
+	Expected []T
+}
+
+func (bm *SuffixMatcher[T]) Match(got []T, _ ...any) bool {
+	return bytes.HasSuffix(toBytes(got), toBytes(bm.Expected))
+}
+
+func (bm *SuffixMatcher[T]) OnTestFailure(got []T, opts ...any) []string {
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return append([]string{"unexpected: []byte should not have suffix: " + hexBytes(bm.Expected)},
+			hexDump("got     : ", got)...,
+		)
+	}
+
+	result := []string{"bytes suffix not matched:"}
+	if len(got) < len(bm.Expected) {
+		// the slices cannot be aligned at the end, so are compared from the start
+		result = append(result, "  got "+strconv.Itoa(len(got))+" bytes, shorter than suffix of "+strconv.Itoa(len(bm.Expected)))
+		return append(result, hexDiff(bm.Expected, got, 0, opts...)...)
+	}
+
+	// offsets in the report are the offsets in the got slice
+	offset := len(got) - len(bm.Expected)
+	return append(result, hexDiff(bm.Expected, got[offset:], offset, opts...)...)
+}
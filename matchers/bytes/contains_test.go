@@ -0,0 +1,135 @@
+package bytes_test
+
+import (
+	"testing"
+
+	. "github.com/blugnu/test"
+)
+
+func TestContainsMatcher(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "expected to contain and does",
+			Act: func() { Expect([]byte("abcdef")).To(ContainBytes([]byte("cde"))) },
+		},
+		{Scenario: "expected to contain and does not",
+			Act: func() { Expect([]byte("abcdef")).To(ContainBytes([]byte{0x01, 0x02})) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: []byte containing: 01 02",
+					"got     : 00000000  61 62 63 64 65 66                                |abcdef|",
+				)
+			},
+		},
+		{Scenario: "expected to not contain and does",
+			Act: func() { Expect([]byte("abcdef")).ToNot(ContainBytes([]byte("de"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"unexpected: []byte should not contain: 64 65",
+					"  found at offset: 3",
+					"got     : 00000000  61 62 63 64 65 66                                |abcdef|",
+				)
+			},
+		},
+		{Scenario: "no expected bytes",
+			Act: func() { Expect([]byte("abcdef")).To(ContainBytes([]byte{})) },
+			Assert: func(result *R) {
+				result.ExpectInvalid("bytes.ContainsMatcher: no expected bytes specified")
+			},
+		},
+		{Scenario: "expected to not contain no expected bytes",
+			Act: func() { Expect([]byte("abcdef")).ToNot(ContainBytes[byte](nil)) },
+			Assert: func(result *R) {
+				result.ExpectInvalid("bytes.ContainsMatcher: no expected bytes specified")
+			},
+		},
+		{Scenario: "custom byte type",
+			Act: func() {
+				type MyByte byte
+				Expect([]MyByte{1, 2, 3}).To(ContainBytes([]MyByte{2, 3}))
+			},
+		},
+	}...))
+}
+
+func TestPrefixMatcher(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "expected prefix and has prefix",
+			Act: func() { Expect([]byte("abcdef")).To(HaveBytePrefix([]byte("abc"))) },
+		},
+		{Scenario: "expected prefix and does not have prefix",
+			Act: func() { Expect([]byte("abcdef")).To(HaveBytePrefix([]byte("abd"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"bytes prefix not matched:",
+					"expected: 00000000  61 62 64                                         |abd|",
+					"        |                 **",
+					"got     : 00000000  61 62 63                                         |abc|",
+				)
+			},
+		},
+		{Scenario: "expected prefix longer than got",
+			Act: func() { Expect([]byte("ab")).To(HaveBytePrefix([]byte("abc"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"bytes prefix not matched:",
+					"  got 2 bytes, shorter than prefix of 3",
+					"expected: 00000000  61 62 63                                         |abc|",
+					"        |                 --",
+					"got     : 00000000  61 62                                            |ab|",
+				)
+			},
+		},
+		{Scenario: "expected to not have prefix and does",
+			Act: func() { Expect([]byte("abc")).ToNot(HaveBytePrefix([]byte("ab"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"unexpected: []byte should not have prefix: 61 62",
+					"got     : 00000000  61 62 63                                         |abc|",
+				)
+			},
+		},
+	}...))
+}
+
+func TestSuffixMatcher(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "expected suffix and has suffix",
+			Act: func() { Expect([]byte("abcdef")).To(HaveByteSuffix([]byte("def"))) },
+		},
+		{Scenario: "expected suffix and does not have suffix",
+			Act: func() { Expect([]byte("abcdef")).To(HaveByteSuffix([]byte("dxf"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"bytes suffix not matched:",
+					"expected: 00000003  64 78 66                                         |dxf|",
+					"        |              **",
+					"got     : 00000003  64 65 66                                         |def|",
+				)
+			},
+		},
+		{Scenario: "expected suffix longer than got",
+			Act: func() { Expect([]byte("ab")).To(HaveByteSuffix([]byte("xab"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"bytes suffix not matched:",
+					"  got 2 bytes, shorter than suffix of 3",
+				)
+			},
+		},
+		{Scenario: "expected to not have suffix and does",
+			Act: func() { Expect([]byte("abc")).ToNot(HaveByteSuffix([]byte("bc"))) },
+			Assert: func(result *R) {
+				result.Expect(
+					"unexpected: []byte should not have suffix: 62 63",
+					"got     : 00000000  61 62 63                                         |abc|",
+				)
+			},
+		},
+	}...))
+}
@@ -48,6 +48,10 @@ func (bm *EqualMatcher[T]) OnTestFailure(got []T, opts ...any) []string {
 	}
 
 	diff, diffs, out := bm.reportInit(want, got)
+	if opt.IsSet(opts, opt.HexDump(true)) {
+		return append(out, hexDiff(want, got, 0, opts...)...)
+	}
+
 	ss, we, ge, pfx, wsfx, gsfx := bm.reportCalc(want, got, diff, diffs)
 
 	expectedBytes := strings.Builder{}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	. "github.com/blugnu/test"
+	"github.com/blugnu/test/opt"
 )
 
 func TestEqualMatcher(t *testing.T) {
@@ -170,6 +171,92 @@ func TestEqualMatcher(t *testing.T) {
 				)
 			},
 		},
+		{Scenario: "hexdump/diffs in multiple regions",
+			Act: func() {
+				got := makeSlice(40, 1)
+				got[35] = 0x41
+				Expect(got).To(EqualBytes(makeSlice(40)), opt.HexDump(true))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"bytes not equal:",
+					"  differences at: [1, 35]",
+					"expected: 00000000  01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10  |................|",
+					"        |              **",
+					"got     : 00000000  01 ff 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10  |................|",
+					"          ...",
+					"expected: 00000020  21 22 23 24 25 26 27 28                          |!\"#$%&'(|",
+					"        |                    **",
+					"got     : 00000020  21 22 23 41 25 26 27 28                          |!\"#A%&'(|",
+				)
+			},
+		},
+		{Scenario: "hexdump/adjacent rows form a single region",
+			Act: func() {
+				got := makeSlice(20, 15)
+				got[16] = 0
+				Expect(got).To(EqualBytes(makeSlice(20)), opt.HexDump(true))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: 00000000  01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f 10  |................|",
+					"        |                                                        **",
+					"got     : 00000000  01 02 03 04 05 06 07 08 09 0a 0b 0c 0d 0e 0f ff  |................|",
+					"expected: 00000010  11 12 13 14                                      |....|",
+					"        |           **",
+					"got     : 00000010  00 12 13 14                                      |....|",
+				)
+			},
+		},
+		{Scenario: "hexdump/different lengths",
+			Act: func() { Expect(makeSlice(3)).To(EqualBytes(makeSlice(5)), opt.HexDump(true)) },
+			Assert: func(result *R) {
+				result.Expect(
+					"bytes not equal:",
+					"  different lengths: expected 5, got 3",
+					"expected: 00000000  01 02 03 04 05                                   |.....|",
+					"        |                    -- --",
+					"got     : 00000000  01 02 03                                         |...|",
+				)
+			},
+		},
+		{Scenario: "hexdump/regions limited",
+			Act: func() {
+				got := makeSlice(80, 0)
+				got[40] = 0
+				got[70] = 0
+				Expect(got).To(EqualBytes(makeSlice(80)), opt.HexDump(true), opt.MaxDiffRegions(2))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"          ...",
+					"expected: 00000020  21 22 23 24 25 26 27 28 29 2a 2b 2c 2d 2e 2f 30  |!\"#$%&'()*+,-./0|",
+					"        |                                   **",
+					"got     : 00000020  21 22 23 24 25 26 27 28 00 2a 2b 2c 2d 2e 2f 30  |!\"#$%&'(.*+,-./0|",
+					"          ... 1 more region with differences not shown",
+				)
+			},
+		},
+		{Scenario: "hexdump/rows in large region limited",
+			Act: func() {
+				want := make([]byte, 16*20)
+				got := make([]byte, len(want))
+				for i := range got {
+					got[i] = 0xff
+				}
+				Expect(got).To(EqualBytes(want), opt.HexDump(true))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: 00000030  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  |................|",
+					"        |           ** ** ** ** ** ** ** ** ** ** ** ** ** ** ** **",
+					"got     : 00000030  ff ff ff ff ff ff ff ff ff ff ff ff ff ff ff ff  |................|",
+					"          ... 192 bytes ...",
+					"expected: 00000100  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  |................|",
+				)
+				Expect(len(result.Report)).To(BeLessThan(40))
+			},
+		},
 		{Scenario: "test passes with custom byte type",
 			Act: func() {
 				type MyByte byte
@@ -0,0 +1,232 @@
+package bytes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blugnu/test/opt"
+)
+
+const (
+	// bytesPerRow is the number of bytes presented in each row of a hexdump
+	bytesPerRow = 16
+
+	// defaultMaxDiffRegions is the maximum number of regions of differences
+	// presented in a hexdump report if not specified by opt.MaxDiffRegions
+	defaultMaxDiffRegions = 3
+
+	// maxDumpRows is the maximum number of rows presented when dumping a
+	// slice in its entirety (rather than regions of differences)
+	maxDumpRows = 16
+
+	// maxRegionRows is the maximum number of rows presented for a region of
+	// differences; the rows in the middle of a larger region are elided
+	maxRegionRows = 8
+)
+
+// maxDiffRegions returns the maximum number of regions of differences to be
+// presented in a report, as specified by any opt.MaxDiffRegions option.  A
+// result of zero indicates no limit.
+func maxDiffRegions(opts ...any) int {
+	if n, ok := opt.Get[opt.MaxDiffRegions](opts); ok {
+		return max(0, int(n))
+	}
+	return defaultMaxDiffRegions
+}
+
+// diffRows returns the indices of the rows of a hexdump comparing two slices
+// that contain at least one difference.
+func diffRows[T ~byte](want, got []T) []int {
+	n := max(len(want), len(got))
+
+	result := []int{}
+	for row := 0; row*bytesPerRow < n; row++ {
+		for i := row * bytesPerRow; i < min(n, (row+1)*bytesPerRow); i++ {
+			if i >= len(want) || i >= len(got) || want[i] != got[i] {
+				result = append(result, row)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// diffRegions groups the indices of rows containing differences into regions
+// of consecutive rows.
+func diffRegions(rows []int) [][]int {
+	var result [][]int
+	for i, row := range rows {
+		if i == 0 || row != rows[i-1]+1 {
+			result = append(result, []int{})
+		}
+		result[len(result)-1] = append(result[len(result)-1], row)
+	}
+	return result
+}
+
+// hexRow formats a row of a hexdump for the bytes of a slice in the range
+// [start, start+bytesPerRow); bytes beyond the end of the slice are presented
+// as blank columns to keep the ASCII gutter aligned.
+func hexRow[T ~byte](b []T, start, offset int) string {
+	hex := strings.Builder{}
+	ascii := strings.Builder{}
+
+	for i := start; i < start+bytesPerRow; i++ {
+		if i > start {
+			hex.WriteString(" ")
+		}
+
+		if i >= len(b) {
+			hex.WriteString("  ")
+			continue
+		}
+
+		fmt.Fprintf(&hex, "%02x", b[i])
+		if c := byte(b[i]); c >= 0x20 && c < 0x7f {
+			ascii.WriteByte(c)
+		} else {
+			ascii.WriteByte('.')
+		}
+	}
+
+	return fmt.Sprintf("%08x  %s  |%s|", offset+start, hex.String(), ascii.String())
+}
+
+// markerRow formats a row of markers identifying the differences between
+// two slices in the range [start, start+bytesPerRow):
+//
+//	**   the bytes differ
+//	--   the byte is missing (expected but not present)
+//	++   the byte is extra (present but not expected)
+func markerRow[T ~byte](want, got []T, start int) string {
+	sb := strings.Builder{}
+	sb.WriteString(strings.Repeat(" ", len("00000000  ")))
+
+	for i := start; i < start+bytesPerRow; i++ {
+		if i > start {
+			sb.WriteString(" ")
+		}
+
+		switch {
+		case i >= len(want) && i >= len(got):
+			sb.WriteString("  ")
+		case i >= len(got):
+			sb.WriteString("--")
+		case i >= len(want):
+			sb.WriteString("++")
+		case want[i] != got[i]:
+			sb.WriteString("**")
+		default:
+			sb.WriteString("  ")
+		}
+	}
+
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// hexDiff returns a hexdump report of the differences between two slices.
+//
+// Each region of rows containing differences is presented with the expected
+// and actual bytes, separated by a row of markers identifying the differences.
+// The offset is added to the offsets presented in the report (for use when the
+// slices being compared are windows into some larger slice).
+//
+// The number of regions presented is limited by opt.MaxDiffRegions (default 3);
+// if there are more regions than this, the number of regions not presented
+// is reported.  The number of rows presented for each region is also limited;
+// the rows in the middle of a region with more rows than this are elided,
+// reporting the number of bytes not presented.
+func hexDiff[T ~byte](want, got []T, offset int, opts ...any) []string {
+	regions := diffRegions(diffRows(want, got))
+
+	limit := maxDiffRegions(opts...)
+	shown := regions
+	if limit > 0 && len(regions) > limit {
+		shown = regions[:limit]
+	}
+
+	result := []string{}
+	for i, region := range shown {
+		if i > 0 {
+			result = append(result, "          ...")
+		}
+		for j, row := range region {
+			if elided := len(region) - maxRegionRows; elided > 0 && j == maxRegionRows/2 {
+				result = append(result, fmt.Sprintf("          ... %d bytes ...", elidedBytes(want, got, row, elided)))
+			}
+			if j >= maxRegionRows/2 && j < len(region)-maxRegionRows/2 {
+				continue
+			}
+
+			start := row * bytesPerRow
+			result = append(result,
+				"expected: "+hexRow(want, start, offset),
+				"        | "+markerRow(want, got, start),
+				"got     : "+hexRow(got, start, offset),
+			)
+		}
+	}
+
+	if n := len(regions) - len(shown); n > 0 {
+		s := "s"
+		if n == 1 {
+			s = ""
+		}
+		result = append(result, fmt.Sprintf("          ... %d more region%s with differences not shown", n, s))
+	}
+
+	return result
+}
+
+// elidedBytes returns the number of bytes in a number of consecutive rows of a
+// hexdump comparing two slices, starting at a specified row
+func elidedBytes[T ~byte](want, got []T, row, rows int) int {
+	n := max(len(want), len(got))
+	return min(n, (row+rows)*bytesPerRow) - row*bytesPerRow
+}
+
+// hexDump returns a hexdump of a slice, prefixing the first row with a
+// specified label and indenting subsequent rows to align with it.
+//
+// If the slice is empty, the label is followed by "<empty>".  The number of
+// rows presented is limited; if the slice exceeds this limit the number of
+// bytes not presented is reported.
+func hexDump[T ~byte](label string, b []T) []string {
+	if len(b) == 0 {
+		return []string{label + "<empty>"}
+	}
+
+	indent := strings.Repeat(" ", len(label))
+
+	result := []string{}
+	for row := 0; row*bytesPerRow < len(b) && row < maxDumpRows; row++ {
+		pfx := indent
+		if row == 0 {
+			pfx = label
+		}
+		result = append(result, pfx+hexRow(b, row*bytesPerRow, 0))
+	}
+
+	if n := len(b) - maxDumpRows*bytesPerRow; n > 0 {
+		result = append(result, fmt.Sprintf("%s... %d more bytes not shown", indent, n))
+	}
+
+	return result
+}
+
+// hexBytes formats a slice of bytes as a space separated sequence of hex
+// values, e.g. "01 02 03"
+func hexBytes[T ~byte](b []T) string {
+	if len(b) == 0 {
+		return "<empty>"
+	}
+
+	sb := strings.Builder{}
+	for i, c := range b {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		fmt.Fprintf(&sb, "%02x", c)
+	}
+	return sb.String()
+}
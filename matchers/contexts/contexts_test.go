@@ -3,6 +3,7 @@ package contexts_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/blugnu/test"
 )
@@ -89,6 +90,89 @@ func TestContext(t *testing.T) {
 					)
 				},
 			},
+			{Scenario: "expect key to have value when the key is not present but other keys are",
+				Act: func() {
+					ctx := context.WithValue(ctxWithKey, "key", "value")
+					ctx = context.WithoutCancel(ctx)
+					ctx, cancel := context.WithTimeout(ctx, time.Second)
+					defer cancel()
+					Expect(ctx).To(HaveContextValue(key(2), "value-1"))
+				},
+				Assert: func(result *R) {
+					result.Expect(
+						"context value: contexts_test.key(2)",
+						"  key not present in context",
+						"  keys in context:",
+						"    string(key)",
+						"    contexts_test.key(1)",
+					)
+				},
+			},
+			{Scenario: "expect key to have value when a key of a different type is present",
+				Act: func() {
+					ctx := context.WithValue(ctxBg, 1, "value-1")
+					Expect(ctx).To(HaveContextValue(key(1), "value-1"))
+				},
+				Assert: func(result *R) {
+					result.Expect(
+						"context value: contexts_test.key(1)",
+						"  key not present in context",
+						"  keys in context:",
+						"    int(1) <== different type",
+					)
+				},
+			},
+			{Scenario: "expect key to have value when the context has no values",
+				Act: func() { Expect(ctxBg).To(HaveContextValue(key(1), "value-1")) },
+				Assert: func(result *R) {
+					result.Expect(
+						"context value: contexts_test.key(1)",
+						"  key not present in context",
+						"  keys in context: <none>",
+					)
+				},
+			},
+			{Scenario: "expect key to have value matching a matcher",
+				Act: func() {
+					Expect(ctxWithKey).To(HaveContextValue(key(1), MatchRegEx(`^value-\d$`)))
+				},
+			},
+			{Scenario: "expect key to have value matching a matcher that does not match",
+				Act: func() {
+					Expect(ctxWithKey).To(HaveContextValue(key(1), ContainString("other")))
+				},
+				Assert: func(result *R) {
+					result.Expect(
+						"context value: contexts_test.key(1)",
+						`  expected: string containing: "other"`,
+						`  got     : "value-1"`,
+					)
+				},
+			},
+			{Scenario: "expect key to not have value matching a matcher that matches",
+				Act: func() {
+					Expect(ctxWithKey).ToNot(HaveContextValue(key(1), ContainString("value")))
+				},
+				Assert: func(result *R) {
+					result.Expect(
+						"context value: contexts_test.key(1)",
+						`  expected: string not containing: "value"`,
+						`  got     : "value-1"`,
+					)
+				},
+			},
+			{Scenario: "expect key to have value matching a matcher of an incompatible type",
+				Act: func() {
+					Expect(ctxWithKey).To(HaveContextValue(key(1), Equal(42)))
+				},
+				Assert: func(result *R) {
+					result.Expect(
+						"context value: contexts_test.key(1)",
+						"  expected value of type: int",
+						"  got: string",
+					)
+				},
+			},
 			{Scenario: "custom value comparison function (type V)",
 				Act: func() {
 					Expect(ctxWithKey).To(HaveContextValue(key(1), "any"), func(a, b string) bool {
@@ -0,0 +1,119 @@
+package contexts
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// contextType is the reflect.Type of the context.Context interface
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// chainKeys walks the chain of contexts from the specified context to the root,
+// returning the keys of any values found in the chain.
+//
+// Keys are returned in the order in which they would be found by ctx.Value(),
+// i.e. the most recently added key first.
+//
+// The chain is walked using reflection over the known context implementation
+// types in the standard library (valueCtx, cancelCtx, timerCtx etc) and any
+// type that embeds a context.Context.  If a context is found that is not a
+// known type and does not embed a context.Context, the walk ends and the
+// type of that context is returned as a string (otherwise an empty string).
+func chainKeys(ctx context.Context) ([]reflect.Value, string) {
+	var keys []reflect.Value
+
+	rv := reflect.ValueOf(ctx)
+	for rv.IsValid() {
+		for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return keys, ""
+			}
+			rv = rv.Elem()
+		}
+
+		if rv.Kind() != reflect.Struct {
+			return keys, rv.Type().String()
+		}
+
+		rt := rv.Type()
+		if rt.PkgPath() == "context" && rt.Name() == "valueCtx" {
+			keys = append(keys, rv.FieldByName("key").Elem())
+		}
+
+		rv = parentOf(rv)
+		if !rv.IsValid() && !isRoot(rt) {
+			return keys, rt.String()
+		}
+	}
+
+	return keys, ""
+}
+
+// parentOf returns the parent of a context, if the context is a struct that
+// embeds a context.Context (directly or via a promoted field) or holds its
+// parent in a context.Context field named 'c' (as does context.withoutCancelCtx).
+//
+// If no parent is identified an invalid (zero) reflect.Value is returned.
+func parentOf(rv reflect.Value) reflect.Value {
+	for _, name := range []string{"Context", "c"} {
+		if f := rv.FieldByName(name); f.IsValid() && f.Type() == contextType {
+			return f
+		}
+	}
+	return reflect.Value{}
+}
+
+// isRoot returns true if the specified type is a context type known to be the
+// root of a context chain (i.e. context.Background() or context.TODO()).
+func isRoot(rt reflect.Type) bool {
+	if rt.PkgPath() != "context" {
+		return false
+	}
+
+	switch rt.Name() {
+	case "emptyCtx", "backgroundCtx", "todoCtx":
+		return true
+	default:
+		return false
+	}
+}
+
+// formatKey formats a key found in a context chain in the same form as
+// keys are identified in test failure reports, i.e. type(value)
+func formatKey(k reflect.Value) string {
+	if !k.IsValid() {
+		return "nil"
+	}
+	return fmt.Sprintf("%s(%v)", k.Type(), k)
+}
+
+// keysReport returns lines for a test failure report listing the keys present
+// in a context.  Any key having the same value as the expected key but of a
+// different type is marked; this is a common cause of a key not being found.
+func keysReport[K comparable](ctx context.Context, expected K) []string {
+	keys, stoppedAt := chainKeys(ctx)
+
+	if len(keys) == 0 && stoppedAt == "" {
+		return []string{"  keys in context: <none>"}
+	}
+
+	result := make([]string, 0, len(keys)+2)
+	result = append(result, "  keys in context:")
+
+	expectedType := reflect.TypeOf(expected)
+	expectedValue := fmt.Sprintf("%v", expected)
+	for _, k := range keys {
+		s := "    " + formatKey(k)
+		if k.IsValid() && k.Type() != expectedType && fmt.Sprintf("%v", k) == expectedValue {
+			s += " <== different type"
+		}
+		result = append(result, s)
+	}
+
+	if stoppedAt != "" {
+		result = append(result, "    ... (unable to walk context of type "+stoppedAt+")")
+	}
+
+	return result
+}
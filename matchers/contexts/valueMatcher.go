@@ -8,6 +8,14 @@ import (
 	"github.com/blugnu/test/opt"
 )
 
+// ValueMatcher is a matcher that tests for a key in a context having an
+// expected value.
+//
+// If the Expected value is itself a matcher (i.e. implements a method of the
+// form Match(T, ...any) bool) the value in the context is tested using that
+// matcher, rather than being compared with the Expected value.  The type of
+// the value in the context must be assignable to the type T accepted by the
+// matcher.
 type ValueMatcher[K comparable, V any] struct {
 	Key      K
 	Expected V
@@ -15,9 +23,16 @@ type ValueMatcher[K comparable, V any] struct {
 
 func (vm *ValueMatcher[K, V]) Match(ctx context.Context, opts ...any) bool {
 	cv := ctx.Value(vm.Key)
+	if cv == nil {
+		return false
+	}
+
+	if m, ok := asValueMatcher(vm.Expected); ok {
+		return m.accepts(cv) && m.match(cv, opts...)
+	}
 
 	v, ok := cv.(V)
-	if !ok || cv == nil {
+	if !ok {
 		return false
 	}
 
@@ -28,24 +43,37 @@ func (vm *ValueMatcher[K, V]) Match(ctx context.Context, opts ...any) bool {
 	return reflect.DeepEqual(v, vm.Expected)
 }
 
-func (km ValueMatcher[K, V]) OnTestFailure(ctx context.Context, opts ...any) []string {
-	got := ctx.Value(km.Key)
+func (vm ValueMatcher[K, V]) OnTestFailure(ctx context.Context, opts ...any) []string {
+	result := []string{
+		fmt.Sprintf("context value: %[1]T(%[1]v)", vm.Key),
+	}
+
+	got := ctx.Value(vm.Key)
 	if got == nil {
-		return []string{
-			fmt.Sprintf("context value: %[1]T(%[1]v):", km.Key),
-			"  key not present in context",
-		}
+		result = append(result, "  key not present in context")
+		return append(result, keysReport(ctx, vm.Key)...)
 	}
 
-	result := []string{
-		fmt.Sprintf("context value: %[1]T(%[1]v)", km.Key),
+	if m, ok := asValueMatcher(vm.Expected); ok {
+		if !m.accepts(got) {
+			return append(result,
+				"  expected value of type: "+m.in.String(),
+				fmt.Sprintf("  got: %T", got),
+			)
+		}
+
+		for _, s := range m.report(got, opts...) {
+			result = append(result, "  "+s)
+		}
+		return result
 	}
+
 	switch opt.IsSet(opts, opt.ToNotMatch(true)) {
 	case true:
-		result = append(result, fmt.Sprintf("  key was not expected to have value: %v", opt.ValueAsString(km.Expected, opts...)))
+		result = append(result, fmt.Sprintf("  key was not expected to have value: %v", opt.ValueAsString(vm.Expected, opts...)))
 	default:
 		gotType := fmt.Sprintf("%T", got)
-		expType := fmt.Sprintf("%T", km.Expected)
+		expType := fmt.Sprintf("%T", vm.Expected)
 		if gotType != expType {
 			result = append(result, "  expected value of type: "+expType)
 			result = append(result, "  got: "+gotType)
@@ -53,9 +81,98 @@ func (km ValueMatcher[K, V]) OnTestFailure(ctx context.Context, opts ...any) []s
 		}
 
 		result = append(result,
-			fmt.Sprintf("  expected: %v", opt.ValueAsString(km.Expected, opts...)),
+			fmt.Sprintf("  expected: %v", opt.ValueAsString(vm.Expected, opts...)),
 			fmt.Sprintf("  got     : %v", opt.ValueAsString(got, opts...)),
 		)
 	}
 	return result
 }
+
+// valueMatcher wraps a matcher supplied as the expected value of a
+// ValueMatcher.  Since the type of the matcher is not known at compile-time
+// the Match and OnTestFailure methods of the matcher are called using
+// reflection.
+type valueMatcher struct {
+	m  reflect.Value
+	in reflect.Type
+}
+
+// asValueMatcher returns a valueMatcher and true if the specified value
+// implements a Match(T, ...any) bool method; otherwise false.
+func asValueMatcher(v any) (valueMatcher, bool) {
+	if v == nil {
+		return valueMatcher{}, false
+	}
+
+	mv := reflect.ValueOf(v)
+	fn := mv.MethodByName("Match")
+	if !fn.IsValid() {
+		return valueMatcher{}, false
+	}
+
+	ft := fn.Type()
+	if !ft.IsVariadic() || ft.NumIn() != 2 || ft.NumOut() != 1 || ft.Out(0).Kind() != reflect.Bool {
+		return valueMatcher{}, false
+	}
+
+	return valueMatcher{m: mv, in: ft.In(0)}, true
+}
+
+// accepts returns true if the specified value can be passed to the
+// Match method of the matcher
+func (vm valueMatcher) accepts(got any) bool {
+	return reflect.TypeOf(got).AssignableTo(vm.in)
+}
+
+// gotValue returns a reflect.Value for the specified value as the type
+// accepted by the matcher
+func (vm valueMatcher) gotValue(got any) reflect.Value {
+	rv := reflect.New(vm.in).Elem()
+	rv.Set(reflect.ValueOf(got))
+	return rv
+}
+
+func (vm valueMatcher) match(got any, opts ...any) bool {
+	result := vm.m.MethodByName("Match").CallSlice([]reflect.Value{
+		vm.gotValue(got),
+		reflect.ValueOf(opts),
+	})
+	return result[0].Bool()
+}
+
+// report returns the test failure report of the matcher, if it implements
+// a supported OnTestFailure method; otherwise a default report is returned.
+func (vm valueMatcher) report(got any, opts ...any) []string {
+	fn := vm.m.MethodByName("OnTestFailure")
+	if fn.IsValid() && fn.Type().IsVariadic() && fn.Type().NumOut() == 1 {
+		var result []reflect.Value
+		switch fn.Type().NumIn() {
+		case 1:
+			result = fn.CallSlice([]reflect.Value{reflect.ValueOf(opts)})
+		case 2:
+			if vm.in.AssignableTo(fn.Type().In(0)) {
+				result = fn.CallSlice([]reflect.Value{vm.gotValue(got), reflect.ValueOf(opts)})
+			}
+		}
+
+		if len(result) == 1 {
+			switch r := result[0].Interface().(type) {
+			case string:
+				return []string{r}
+			case []string:
+				return r
+			}
+		}
+	}
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			fmt.Sprintf("expected: value not matching: %T", vm.m.Interface()),
+			"got     : " + opt.ValueAsString(got, opts...),
+		}
+	}
+	return []string{
+		fmt.Sprintf("expected: value matching: %T", vm.m.Interface()),
+		"got     : " + opt.ValueAsString(got, opts...),
+	}
+}
@@ -0,0 +1,44 @@
+package matcher
+
+import (
+	"fmt"
+
+	"github.com/blugnu/test/opt"
+)
+
+// Report returns the test failure report of a matcher for a given value.
+//
+// This is used by matchers that compose other matchers (e.g. to test some
+// part of a value) to incorporate the failure report of the composed matcher
+// in their own failure report.
+//
+// The report is obtained from the OnTestFailure method implemented by the
+// matcher, in any of the forms supported by Expect().  If the matcher does not
+// implement a supported OnTestFailure method, a default report is returned.
+func Report[T any](m ForType[T], got T, opts ...any) []string {
+	switch m := m.(type) {
+	case interface{ OnTestFailure(...any) string }:
+		return []string{m.OnTestFailure(opts...)}
+	case interface{ OnTestFailure(...any) []string }:
+		return m.OnTestFailure(opts...)
+	case interface{ OnTestFailure(T, ...any) string }:
+		return []string{m.OnTestFailure(got, opts...)}
+	case interface{ OnTestFailure(T, ...any) []string }:
+		return m.OnTestFailure(got, opts...)
+	case interface{ OnTestFailure(any, ...any) string }:
+		return []string{m.OnTestFailure(got, opts...)}
+	case interface{ OnTestFailure(any, ...any) []string }:
+		return m.OnTestFailure(got, opts...)
+	}
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			fmt.Sprintf("expected: value not matching: %T", m),
+			"got     : " + opt.ValueAsString(got, opts...),
+		}
+	}
+	return []string{
+		fmt.Sprintf("expected: value matching: %T", m),
+		"got     : " + opt.ValueAsString(got, opts...),
+	}
+}
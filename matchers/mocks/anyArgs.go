@@ -0,0 +1,10 @@
+package mocks
+
+// AnyArgsMatcher is a matcher that matches any value.  It is used to
+// configure an expected call to a mock function where arguments must be
+// recorded but the values of those arguments are not significant.
+type AnyArgsMatcher[A any] struct{}
+
+func (AnyArgsMatcher[A]) Match(A, ...any) bool {
+	return true
+}
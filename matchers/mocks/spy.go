@@ -0,0 +1,168 @@
+package mocks
+
+import (
+	"fmt"
+
+	"github.com/blugnu/test/matchers/matcher"
+	"github.com/blugnu/test/opt"
+	"github.com/blugnu/test/test"
+)
+
+// CallCounter is an interface implemented by spies, providing the number of
+// calls recorded by the spy.
+type CallCounter interface {
+	CallCount() int
+}
+
+// ArgsRecorder is an interface implemented by spies with arguments of type A,
+// providing the arguments of the calls recorded by the spy.
+type ArgsRecorder[A any] interface {
+	Args() []A
+}
+
+// plural returns a count of some noun, e.g. "1 call", "2 calls"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// calls describes a number of calls, e.g. "no calls", "1 call", "2 calls"
+func calls(n int) string {
+	if n == 0 {
+		return "no calls"
+	}
+	return plural(n, "call")
+}
+
+// CalledMatcher is a matcher that tests whether a spy has recorded any calls.
+//
+// The failure report is based on the calls recorded by the spy when the
+// report is produced; no state is retained by the matcher, so a matcher value
+// may be reused.
+type CalledMatcher struct{}
+
+func (m CalledMatcher) Match(got any, _ ...any) bool {
+	spy, ok := got.(CallCounter)
+	return ok && spy.CallCount() > 0
+}
+
+func (m CalledMatcher) OnTestFailure(got any, opts ...any) []string {
+	spy, ok := got.(CallCounter)
+	if !ok {
+		test.T().Helper()
+		test.Invalid(fmt.Sprintf("mocks.CalledMatcher: requires a spy: got %T", got))
+	}
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			"expected: no calls",
+			"got     : " + calls(spy.CallCount()),
+		}
+	}
+
+	return []string{
+		"expected: at least 1 call",
+		"got     : " + calls(spy.CallCount()),
+	}
+}
+
+// CalledTimesMatcher is a matcher that tests whether a spy has recorded a
+// specified number of calls.
+type CalledTimesMatcher struct {
+	Expected int
+}
+
+func (m CalledTimesMatcher) Match(got any, _ ...any) bool {
+	spy, ok := got.(CallCounter)
+	return ok && spy.CallCount() == m.Expected
+}
+
+func (m CalledTimesMatcher) OnTestFailure(got any, opts ...any) []string {
+	spy, ok := got.(CallCounter)
+	if !ok {
+		test.T().Helper()
+		test.Invalid(fmt.Sprintf("mocks.CalledTimesMatcher: requires a spy: got %T", got))
+	}
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			"expected: other than " + calls(m.Expected),
+			"got     : " + calls(spy.CallCount()),
+		}
+	}
+
+	return []string{
+		"expected: " + calls(m.Expected),
+		"got     : " + calls(spy.CallCount()),
+	}
+}
+
+// CalledWithMatcher is a matcher that tests whether a spy has recorded any
+// call with arguments satisfying a matcher.
+type CalledWithMatcher[A any] struct {
+	Matcher matcher.ForType[A]
+}
+
+func (m CalledWithMatcher[A]) Match(got any, opts ...any) bool {
+	spy, ok := got.(ArgsRecorder[A])
+	return ok && m.matching(spy.Args(), opts...) > 0
+}
+
+// matching returns the (1-based) number of the first call with arguments
+// satisfying the matcher, or 0 if there is no such call
+func (m CalledWithMatcher[A]) matching(args []A, opts ...any) int {
+	opts = opt.Unset(opts, opt.ToNotMatch(true))
+	for i, a := range args {
+		if m.Matcher.Match(a, opts...) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (m CalledWithMatcher[A]) OnTestFailure(got any, opts ...any) []string {
+	spy, ok := got.(ArgsRecorder[A])
+	if !ok {
+		var a A
+		test.T().Helper()
+		test.Invalid(fmt.Sprintf("mocks.CalledWithMatcher: requires a spy with arguments of type %T: got %T", a, got))
+	}
+
+	// the args are obtained once, so that the report is consistent even if
+	// the spy records further calls while the report is produced
+	args := spy.Args()
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		if n := m.matching(args, opts...); n > 0 {
+			return []string{
+				"expected: no call with matching args",
+				fmt.Sprintf("got     : call %d with args: %v", n, args[n-1]),
+			}
+		}
+		return []string{
+			"expected: no call with matching args",
+			"got     : " + calls(len(args)),
+		}
+	}
+
+	if len(args) == 0 {
+		return []string{
+			"expected: a call with matching args",
+			"got     : no calls",
+		}
+	}
+
+	report := []string{
+		"expected: a call with matching args",
+		"got     : " + calls(len(args)) + ", none with matching args:",
+	}
+	for i, a := range args {
+		report = append(report, fmt.Sprintf("call %d:", i+1))
+		for _, s := range matcher.Report(m.Matcher, a) {
+			report = append(report, "  "+s)
+		}
+	}
+	return report
+}
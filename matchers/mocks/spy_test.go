@@ -0,0 +1,210 @@
+package mocks_test
+
+import (
+	"testing"
+
+	. "github.com/blugnu/test"
+)
+
+func double(n int) (int, error) { return n * 2, nil }
+
+func TestCalledMatcher(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "called",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+
+				Expect(spy).Should(HaveBeenCalled())
+			},
+		},
+		{Scenario: "not called",
+			Act: func() {
+				Expect(Spy(double)).Should(HaveBeenCalled())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: at least 1 call",
+					"got     : no calls",
+				)
+			},
+		},
+		{Scenario: "not called when called",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+				_, _ = spy.Call(2)
+
+				Expect(spy).ShouldNot(HaveBeenCalled())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: no calls",
+					"got     : 2 calls",
+				)
+			},
+		},
+		{Scenario: "not a spy",
+			Act: func() {
+				Expect(42).Should(HaveBeenCalled())
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid("mocks.CalledMatcher: requires a spy: got int")
+			},
+		},
+		{Scenario: "matcher reused with a value that is not a spy",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+
+				m := HaveBeenCalled()
+				Expect(spy).Should(m)
+				Expect(42).Should(m)
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid("mocks.CalledMatcher: requires a spy: got int")
+			},
+		},
+	}...))
+}
+
+func TestCalledTimesMatcher(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "called expected times",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+				_, _ = spy.Call(2)
+
+				Expect(spy).Should(HaveBeenCalledTimes(2))
+			},
+		},
+		{Scenario: "called other than expected times",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+
+				Expect(spy).Should(HaveBeenCalledTimes(2))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: 2 calls",
+					"got     : 1 call",
+				)
+			},
+		},
+		{Scenario: "not called expected times when called expected times",
+			Act: func() {
+				Expect(Spy(double)).ShouldNot(HaveBeenCalledTimes(0))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: other than no calls",
+					"got     : no calls",
+				)
+			},
+		},
+		{Scenario: "not a spy",
+			Act: func() {
+				Expect("spy").Should(HaveBeenCalledTimes(1))
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid("mocks.CalledTimesMatcher: requires a spy: got string")
+			},
+		},
+		{Scenario: "matcher reused with another spy",
+			Act: func() {
+				m := HaveBeenCalledTimes(1)
+
+				first := Spy(double)
+				_, _ = first.Call(1)
+				_, _ = first.Call(2)
+				Expect(first).ShouldNot(m)
+
+				second := Spy(double)
+				Expect(second).Should(m)
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: 1 call",
+					"got     : no calls",
+				)
+			},
+		},
+	}...))
+}
+
+func TestCalledWithMatcher(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "called with matching args",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+				_, _ = spy.Call(2)
+
+				Expect(spy).Should(HaveBeenCalledWith(Equal(2)))
+			},
+		},
+		{Scenario: "not called",
+			Act: func() {
+				Expect(Spy(double)).Should(HaveBeenCalledWith(Equal(2)))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: a call with matching args",
+					"got     : no calls",
+				)
+			},
+		},
+		{Scenario: "not called with matching args",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+				_, _ = spy.Call(3)
+
+				Expect(spy).Should(HaveBeenCalledWith(Equal(2)))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: a call with matching args",
+					"got     : 2 calls, none with matching args:",
+					"call 1:",
+					"  expected 2, got 1",
+					"call 2:",
+					"  expected 2, got 3",
+				)
+			},
+		},
+		{Scenario: "not called with matching args when called with matching args",
+			Act: func() {
+				spy := Spy(double)
+				_, _ = spy.Call(1)
+				_, _ = spy.Call(2)
+
+				Expect(spy).ShouldNot(HaveBeenCalledWith(Equal(2)))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: no call with matching args",
+					"got     : call 2 with args: 2",
+				)
+			},
+		},
+		{Scenario: "spy with different argument type",
+			Act: func() {
+				spy := Spy(double)
+
+				Expect(spy).Should(HaveBeenCalledWith(Equal("2")))
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid("mocks.CalledWithMatcher: requires a spy with arguments of type string: got *test.SpyFn[int,int]")
+			},
+		},
+	}...))
+}
@@ -0,0 +1,79 @@
+package strings
+
+import (
+	"strings"
+
+	"github.com/blugnu/test/opt"
+)
+
+// ContainsInOrderMatch is a matcher that tests whether a string contains
+// each of a number of expected substrings, in order and without overlapping.
+type ContainsInOrderMatch struct {
+	Expected []string
+}
+
+// find returns the ranges in the string at which each expected substring was
+// found, in order.  If any substring is not found, the ranges of the substrings
+// that were found are returned with the index of the first substring not found;
+// otherwise the index returned is -1.
+//
+// The ranges are byte offsets in the (unfolded) string.
+func (m ContainsInOrderMatch) find(got string, opts ...any) ([][2]int, int) {
+	folded, offsets := foldOffsets(got, opts...)
+
+	result := make([][2]int, 0, len(m.Expected))
+	pos := 0
+	for i, s := range m.Expected {
+		s = fold(s, opts...)
+		idx := strings.Index(folded[pos:], s)
+		if idx == -1 {
+			return result, i
+		}
+		result = append(result, [2]int{offsets[pos+idx], offsets[pos+idx+len(s)]})
+		pos += idx + len(s)
+	}
+	return result, -1
+}
+
+func (m ContainsInOrderMatch) Match(got string, opts ...any) bool {
+	_, missing := m.find(got, opts...)
+	return missing == -1
+}
+
+func (m ContainsInOrderMatch) OnTestFailure(got string, opts ...any) []string {
+	expected := make([]string, 0, len(m.Expected))
+	for _, s := range m.Expected {
+		expected = append(expected, opt.ValueAsString(s, opts...))
+	}
+
+	found, missing := m.find(got, opts...)
+
+	var result []string
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		result = []string{
+			"expected: string not containing (in order): " + strings.Join(expected, ", "),
+			"got     : " + opt.ValueAsString(got, opts...),
+			markers(got, found, opts...),
+		}
+	} else {
+		result = []string{
+			"expected: string containing (in order): " + strings.Join(expected, ", "),
+			"got     : " + opt.ValueAsString(got, opts...),
+		}
+		if len(found) > 0 {
+			result = append(result, markers(got, found, opts...))
+		}
+
+		switch missing {
+		case 0:
+			result = append(result, "missing : "+expected[missing])
+		default:
+			result = append(result, "missing : "+expected[missing]+" (after "+expected[missing-1]+")")
+		}
+	}
+
+	if opt.IsSet(opts, opt.CaseSensitive(false)) {
+		result = append(result, caseInsensitive)
+	}
+	return result
+}
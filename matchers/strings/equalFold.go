@@ -0,0 +1,66 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blugnu/test/opt"
+)
+
+// EqualFoldMatch is a matcher that tests whether a string is equal to an
+// expected string, ignoring differences in case (using strings.EqualFold).
+type EqualFoldMatch struct {
+	Expected string
+}
+
+func (m EqualFoldMatch) Match(got string, _ ...any) bool {
+	return strings.EqualFold(got, m.Expected)
+}
+
+func (m EqualFoldMatch) OnTestFailure(got string, opts ...any) []string {
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			"expected: string not equal (ignoring case) to: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+		}
+	}
+
+	i := equalFoldPrefixLen(got, m.Expected)
+	return []string{
+		"expected: string equal (ignoring case) to: " + opt.ValueAsString(m.Expected, opts...),
+		"got     : " + opt.ValueAsString(got, opts...),
+		markers(got, [][2]int{{i, i}}, opts...),
+	}
+}
+
+// equalFoldPrefixLen returns the length (in bytes) of the longest prefix of a
+// that is equal to a prefix of b under simple Unicode case folding, comparing
+// rune by rune as for strings.EqualFold.
+func equalFoldPrefixLen(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, na := utf8.DecodeRuneInString(a[i:])
+		rb, nb := utf8.DecodeRuneInString(b[j:])
+		if !equalFoldRune(ra, rb) {
+			break
+		}
+		i += na
+		j += nb
+	}
+	return i
+}
+
+// equalFoldRune returns true if two runes are equal under simple Unicode case
+// folding, i.e. if one is in the orbit of the other under unicode.SimpleFold.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package strings
+
+import (
+	"strings"
+
+	"github.com/blugnu/test/opt"
+)
+
+// PrefixMatch is a matcher that tests whether a string begins with
+// an expected prefix.
+type PrefixMatch struct {
+	Expected string
+}
+
+func (m PrefixMatch) Match(got string, opts ...any) bool {
+	return strings.HasPrefix(fold(got, opts...), fold(m.Expected, opts...))
+}
+
+func (m PrefixMatch) OnTestFailure(got string, opts ...any) []string {
+	var result []string
+
+	// offsets in the folded string are mapped to offsets in got
+	folded, offsets := foldOffsets(got, opts...)
+	expected := fold(m.Expected, opts...)
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		result = []string{
+			"expected: string not beginning with: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+			markers(got, [][2]int{{0, offsets[len(expected)]}}, opts...),
+		}
+	} else {
+		// identify the first character that differs from the prefix
+		i := offsets[commonPrefixLen(folded, expected)]
+		result = []string{
+			"expected: string beginning with: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+			markers(got, [][2]int{{i, i}}, opts...),
+		}
+	}
+
+	if opt.IsSet(opts, opt.CaseSensitive(false)) {
+		result = append(result, caseInsensitive)
+	}
+	return result
+}
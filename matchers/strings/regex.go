@@ -1,17 +1,91 @@
 package strings
 
 import (
+	"fmt"
 	"regexp"
+	"regexp/syntax"
+	"strconv"
 
+	"github.com/blugnu/test/matchers/matcher"
 	"github.com/blugnu/test/opt"
+	"github.com/blugnu/test/test"
 )
 
+// RegExMatch is a matcher that tests whether a string contains a match for
+// a regular expression, optionally testing the values of capture groups in
+// the (first) match using other matchers.
 type RegExMatch struct {
 	Expected *regexp.Regexp
+
+	groups []groupMatch
+}
+
+// groupMatch identifies a capture group in a regular expression and a
+// matcher to be applied to the value captured by that group.
+type groupMatch struct {
+	name    string
+	idx     int
+	matcher matcher.ForType[string]
+}
+
+// WithGroup returns a copy of the matcher that additionally tests the value
+// captured by a specified group using a matcher, e.g.:
+//
+//	Expect(s).To(MatchRegEx(`id=(?P<id>\d+)`).WithGroup("id", Equal("42")))
+//
+// The group may be identified by name or by index (as a string, e.g. "1");
+// if the regular expression does not have the specified group, or no matcher
+// is specified, WithGroup panics with ErrInvalidArgument.
+//
+// Groups are tested against the first match in the string.
+func (m RegExMatch) WithGroup(name string, gm matcher.ForType[string]) RegExMatch {
+	idx := m.Expected.SubexpIndex(name)
+	if idx == -1 {
+		if n, err := strconv.Atoi(name); err == nil && n > 0 && n <= m.Expected.NumSubexp() {
+			idx = n
+		}
+	}
+
+	switch {
+	case idx == -1:
+		panic(fmt.Errorf("MatchRegEx.WithGroup: %w: regex %q has no group %q", test.ErrInvalidArgument, m.Expected, name))
+	case gm == nil:
+		panic(fmt.Errorf("MatchRegEx.WithGroup: %w: a matcher must be specified", test.ErrInvalidArgument))
+	}
+
+	groups := make([]groupMatch, len(m.groups), len(m.groups)+1)
+	copy(groups, m.groups)
+	m.groups = append(groups, groupMatch{name: name, idx: idx, matcher: gm})
+
+	return m
+}
+
+// failedGroup returns the first group that is not satisfied by the first
+// match in a string, with the value captured by that group.  If there is
+// no match, or all groups are satisfied, nil is returned.
+func (m RegExMatch) failedGroup(got string, opts ...any) (*groupMatch, string) {
+	match := m.Expected.FindStringSubmatch(got)
+	if match == nil {
+		return nil, ""
+	}
+
+	opts = opt.Unset(opts, opt.ToNotMatch(true))
+	for i := range m.groups {
+		g := &m.groups[i]
+		if !g.matcher.Match(match[g.idx], opts...) {
+			return g, match[g.idx]
+		}
+	}
+	return nil, ""
 }
 
 func (m RegExMatch) Match(got string, opts ...any) bool {
-	return m.Expected.Match([]byte(got))
+	if !m.Expected.MatchString(got) {
+		return false
+	}
+
+	g, _ := m.failedGroup(got, opts...)
+	return g == nil
 }
 
 func (m RegExMatch) OnTestFailure(got string, opts ...any) []string {
@@ -19,11 +93,146 @@ func (m RegExMatch) OnTestFailure(got string, opts ...any) []string {
 		return []string{
 			"expected: string with no match for: " + opt.ValueAsString(m.Expected.String(), opts...),
 			"got     : " + opt.ValueAsString(got, opts...),
-			"matched : " + opt.ValueAsString(string(m.Expected.Find([]byte(got))), opts...),
+			"matched : " + opt.ValueAsString(m.Expected.FindString(got), opts...),
 		}
 	}
-	return []string{
+
+	result := []string{
 		"expected: string containing match for: " + opt.ValueAsString(m.Expected.String(), opts...),
 		"got     : " + opt.ValueAsString(got, opts...),
 	}
+
+	if g, v := m.failedGroup(got, opts...); g != nil {
+		loc := m.Expected.FindStringSubmatchIndex(got)
+		if start, end := loc[2*g.idx], loc[2*g.idx+1]; start != -1 {
+			result = append(result, markers(got, [][2]int{{start, end}}, opts...))
+		}
+
+		result = append(result, fmt.Sprintf("group %q:", g.name))
+		for _, s := range matcher.Report(g.matcher, v, opt.Unset(opts, opt.ToNotMatch(true))...) {
+			result = append(result, "  "+s)
+		}
+		return result
+	}
+
+	if prefix, loc := partialMatch(m.Expected, got); loc != nil {
+		result = append(result,
+			markers(got, [][2]int{{loc[0], loc[1]}}, opts...),
+			"matched : "+opt.ValueAsString(got[loc[0]:loc[1]], opts...)+
+				" (by pattern prefix: "+opt.ValueAsString(prefix, opts...)+")",
+		)
+	}
+
+	return result
+}
+
+// partialMatch identifies the longest prefix of a regular expression that
+// matches some part of a string, returning that prefix of the expression with
+// the location of the text it matched.  This helps to identify where an
+// expression fails to match a string.
+//
+// Prefixes are formed from the elements of the expression (literals, groups,
+// classes etc) at the top-level of the expression; literals are further broken
+// down into individual characters.
+//
+// If no prefix of the expression matches any part of the string, or the
+// expression cannot be parsed, an empty string and nil are returned.
+func partialMatch(re *regexp.Regexp, got string) (string, []int) {
+	ast, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return "", nil
+	}
+
+	subs := []*syntax.Regexp{ast}
+	if ast.Op == syntax.OpConcat {
+		subs = ast.Sub
+	}
+
+	// build the candidate prefixes, from shortest to longest; the complete
+	// expression is not a candidate (we already know that it does not match)
+	candidates := make([]*syntax.Regexp, 0, len(subs))
+	for i, sub := range subs {
+		if sub.Op == syntax.OpLiteral {
+			for j := 1; j < len(sub.Rune); j++ {
+				lit := *sub
+				lit.Rune = sub.Rune[:j]
+				candidates = append(candidates, concat(subs[:i], &lit))
+			}
+		}
+		if i < len(subs)-1 {
+			candidates = append(candidates, concat(subs[:i], sub))
+		}
+	}
+
+	for i := len(candidates) - 1; i >= 0; i-- {
+		expr := candidates[i].String()
+		pre, err := regexp.Compile(expr)
+		if err != nil {
+			continue
+		}
+
+		// an empty match is not helpful
+		if loc := pre.FindStringIndex(got); loc != nil && loc[1] > loc[0] {
+			return expr, loc
+		}
+	}
+
+	return "", nil
+}
+
+// concat returns a regular expression formed by concatenating a slice of
+// expressions with an additional expression
+func concat(subs []*syntax.Regexp, sub *syntax.Regexp) *syntax.Regexp {
+	if len(subs) == 0 {
+		return sub
+	}
+
+	result := make([]*syntax.Regexp, len(subs), len(subs)+1)
+	copy(result, subs)
+
+	return &syntax.Regexp{
+		Op:  syntax.OpConcat,
+		Sub: append(result, sub),
+	}
+}
+
+// RegExCountMatch is a matcher that tests whether a string contains a
+// specific number of (non-overlapping) matches for a regular expression.
+type RegExCountMatch struct {
+	Expected *regexp.Regexp
+	Count    int
+}
+
+func (m RegExCountMatch) Match(got string, _ ...any) bool {
+	return len(m.Expected.FindAllStringIndex(got, -1)) == m.Count
+}
+
+func (m RegExCountMatch) OnTestFailure(got string, opts ...any) []string {
+	found := m.Expected.FindAllStringIndex(got, -1)
+
+	plural := func(n int) string {
+		if n == 1 {
+			return "1 match"
+		}
+		return strconv.Itoa(n) + " matches"
+	}
+
+	ranges := make([][2]int, 0, len(found))
+	for _, loc := range found {
+		ranges = append(ranges, [2]int{loc[0], loc[1]})
+	}
+
+	result := make([]string, 2, 3)
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		result[0] = "expected: not " + plural(m.Count) + " for: " + opt.ValueAsString(m.Expected.String(), opts...)
+	} else {
+		result[0] = "expected: " + plural(m.Count) + " for: " + opt.ValueAsString(m.Expected.String(), opts...)
+	}
+	result[1] = "got     : " + opt.ValueAsString(got, opts...)
+
+	if len(ranges) > 0 {
+		result = append(result, markers(got, ranges, opts...))
+	}
+
+	return append(result, "found   : "+plural(len(found)))
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	. "github.com/blugnu/test"
+	"github.com/blugnu/test/matchers/strings"
 	"github.com/blugnu/test/opt"
 )
 
@@ -123,6 +124,401 @@ func TestStringMatcher(t *testing.T) {
 			},
 		},
 
+		// HavePrefix tests
+		{Scenario: "HavePrefix/has prefix",
+			Act: func() { Expect("abcdef").To(HavePrefix("abc")) },
+		},
+		{Scenario: "HavePrefix/has prefix (case-insensitive)",
+			Act: func() { Expect("ABCdef").To(HavePrefix("abc"), opt.CaseSensitive(false)) },
+		},
+		{Scenario: "HavePrefix/does not have prefix",
+			Act: func() { Expect("abcdef").To(HavePrefix("abd")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string beginning with: "abd"`,
+					`got     : "abcdef"`,
+					`             ^`,
+				)
+			},
+		},
+		{Scenario: "ToNot(HavePrefix)/has prefix",
+			Act: func() { Expect("abcdef").ToNot(HavePrefix("ab")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not beginning with: "ab"`,
+					`got     : "abcdef"`,
+					`           ^^`,
+				)
+			},
+		},
+		{Scenario: "ToNot(HavePrefix)/has prefix (case-insensitive, non-ASCII)",
+			Act: func() {
+				Expect("\u212Ab").ToNot(HavePrefix("k"), opt.CaseSensitive(false), opt.UnquotedStrings())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not beginning with: k`,
+					"got     : \u212Ab",
+					`          ^`,
+					`(case insensitive comparison)`,
+				)
+			},
+		},
+		{Scenario: "HavePrefix/empty string",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("abc").To(HavePrefix(""))
+			},
+		},
+
+		// HaveSuffix tests
+		{Scenario: "HaveSuffix/has suffix",
+			Act: func() { Expect("abcdef").To(HaveSuffix("def")) },
+		},
+		{Scenario: "HaveSuffix/does not have suffix",
+			Act: func() { Expect("abcdef").To(HaveSuffix("xef")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string ending with: "xef"`,
+					`got     : "abcdef"`,
+					`              ^`,
+				)
+			},
+		},
+		{Scenario: "HaveSuffix/does not have suffix (case-insensitive, unquoted)",
+			Act: func() {
+				Expect("abcDEF").To(HaveSuffix("xdef"), opt.CaseSensitive(false), opt.UnquotedStrings())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string ending with: xdef`,
+					`got     : abcDEF`,
+					`            ^`,
+					`(case insensitive comparison)`,
+				)
+			},
+		},
+		{Scenario: "ToNot(HaveSuffix)/has suffix",
+			Act: func() { Expect("abcdef").ToNot(HaveSuffix("ef")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not ending with: "ef"`,
+					`got     : "abcdef"`,
+					`               ^^`,
+				)
+			},
+		},
+		{Scenario: "ToNot(HaveSuffix)/has suffix (case-insensitive, non-ASCII)",
+			Act: func() {
+				// the Kelvin sign (3 bytes) is folded to 'k' (1 byte)
+				Expect("kb").ToNot(HaveSuffix("\u212Ab"), opt.CaseSensitive(false), opt.UnquotedStrings())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: string not ending with: \u212Ab",
+					`got     : kb`,
+					`          ^^`,
+					`(case insensitive comparison)`,
+				)
+			},
+		},
+		{Scenario: "HaveSuffix/does not have suffix (case-insensitive, non-ASCII)",
+			Act: func() {
+				Expect("\u212Aelvin").To(HaveSuffix("xelvin"), opt.CaseSensitive(false), opt.UnquotedStrings())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string ending with: xelvin`,
+					"got     : \u212Aelvin",
+					`          ^`,
+					`(case insensitive comparison)`,
+				)
+			},
+		},
+		{Scenario: "HaveSuffix/empty string",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("abc").To(HaveSuffix(""))
+			},
+		},
+
+		// EqualFold tests
+		{Scenario: "EqualFold/equal",
+			Act: func() { Expect("Hello").To(EqualFold("hELLO")) },
+		},
+		{Scenario: "EqualFold/not equal",
+			Act: func() { Expect("Hello").To(EqualFold("help")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string equal (ignoring case) to: "help"`,
+					`got     : "Hello"`,
+					`              ^`,
+				)
+			},
+		},
+		{Scenario: "EqualFold/not equal (case folding changes length)",
+			Act: func() { Expect("İx").To(EqualFold("İy")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string equal (ignoring case) to: "İy"`,
+					`got     : "İx"`,
+					`            ^`,
+				)
+			},
+		},
+		{Scenario: "EqualFold/equal (simple folding)",
+			Act: func() { Expect("ſtop \u212A").To(EqualFold("STOP k")) },
+		},
+		{Scenario: "ToNot(EqualFold)/equal",
+			Act: func() { Expect("Hello").ToNot(EqualFold("hello")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not equal (ignoring case) to: "hello"`,
+					`got     : "Hello"`,
+				)
+			},
+		},
+
+		// EqualIgnoringWhitespace tests
+		{Scenario: "EqualIgnoringWhitespace/equal",
+			Act: func() { Expect("  a\tb\n c ").To(EqualIgnoringWhitespace("a b c")) },
+		},
+		{Scenario: "EqualIgnoringWhitespace/equal (case-insensitive)",
+			Act: func() { Expect("A  B").To(EqualIgnoringWhitespace("a b"), opt.CaseSensitive(false)) },
+		},
+		{Scenario: "EqualIgnoringWhitespace/not equal",
+			Act: func() { Expect("a  b\td").To(EqualIgnoringWhitespace("a b c")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`strings not equal (ignoring whitespace):`,
+					`expected: "a b c"`,
+					`got     : "a  b\td"`,
+					`                 ^`,
+				)
+			},
+		},
+		{Scenario: "EqualIgnoringWhitespace/not equal (leading and internal whitespace)",
+			Act: func() { Expect(" \t a  b   d ").To(EqualIgnoringWhitespace("a b c")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`strings not equal (ignoring whitespace):`,
+					`expected: "a b c"`,
+					`got     : " \t a  b   d "`,
+					`                      ^`,
+				)
+			},
+		},
+		{Scenario: "EqualIgnoringWhitespace/not equal (case-insensitive)",
+			Act: func() {
+				Expect("  İ  x").To(EqualIgnoringWhitespace("i̇ y"), opt.CaseSensitive(false))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`strings not equal (ignoring whitespace):`,
+					`expected: "i̇ y"`,
+					`got     : "  İ  x"`,
+					`                ^`,
+					`(case insensitive comparison)`,
+				)
+			},
+		},
+		{Scenario: "ToNot(EqualIgnoringWhitespace)/equal",
+			Act: func() { Expect("a  b").ToNot(EqualIgnoringWhitespace("a b")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not equal (ignoring whitespace) to: "a b"`,
+					`got     : "a  b"`,
+				)
+			},
+		},
+
+		// ContainStringsInOrder tests
+		{Scenario: "ContainStringsInOrder/contains in order",
+			Act: func() { Expect("one two three").To(ContainStringsInOrder("one", "three")) },
+		},
+		{Scenario: "ContainStringsInOrder/contains in order (case-insensitive)",
+			Act: func() {
+				Expect("One Two Three").To(ContainStringsInOrder("one", "three"), opt.CaseSensitive(false))
+			},
+		},
+		{Scenario: "ContainStringsInOrder/contains out of order",
+			Act: func() { Expect("one two three").To(ContainStringsInOrder("two", "one")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string containing (in order): "two", "one"`,
+					`got     : "one two three"`,
+					`               ^^^`,
+					`missing : "one" (after "two")`,
+				)
+			},
+		},
+		{Scenario: "ContainStringsInOrder/first string not present",
+			Act: func() { Expect("abc").To(ContainStringsInOrder("x")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string containing (in order): "x"`,
+					`got     : "abc"`,
+					`missing : "x"`,
+				)
+			},
+		},
+		{Scenario: "ToNot(ContainStringsInOrder)/contains in order",
+			Act: func() { Expect("one two three").ToNot(ContainStringsInOrder("one", "three")) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not containing (in order): "one", "three"`,
+					`got     : "one two three"`,
+					`           ^^^     ^^^^^`,
+				)
+			},
+		},
+		{Scenario: "ToNot(ContainStringsInOrder)/contains in order (case-insensitive, non-ASCII)",
+			Act: func() {
+				// U+0130 (2 bytes) is folded to "i\u0307" (3 bytes); the Kelvin
+				// sign (3 bytes) is folded to 'k' (1 byte)
+				Expect("\u0130stanbul \u212Aelvin").ToNot(ContainStringsInOrder("stanbul", "kelvin"),
+					opt.CaseSensitive(false),
+					opt.UnquotedStrings(),
+				)
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string not containing (in order): stanbul, kelvin`,
+					"got     : \u0130stanbul \u212Aelvin",
+					`           ^^^^^^^ ^^^^^^`,
+					`(case insensitive comparison)`,
+				)
+			},
+		},
+		{Scenario: "ContainStringsInOrder/no strings",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("abc").To(ContainStringsInOrder())
+			},
+		},
+		{Scenario: "ContainStringsInOrder/empty string",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("abc").To(ContainStringsInOrder("a", ""))
+			},
+		},
+
+		// MatchRegEx partial match reporting
+		{Scenario: "To(Match)/does not match/partial match reported",
+			Act: func() { Expect("id=abc;").To(MatchRegEx(`id=(?P<id>\d+);`)) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string containing match for: "id=(?P<id>\\d+);"`,
+					`got     : "id=abc;"`,
+					`           ^^^`,
+					`matched : "id=" (by pattern prefix: "id=")`,
+				)
+			},
+		},
+		{Scenario: "To(Match)/does not match/partial literal match reported",
+			Act: func() { Expect("key: id-42").To(MatchRegEx(`id=\d+`)) },
+			Assert: func(result *R) {
+				result.Expect(
+					`got     : "key: id-42"`,
+					`                ^^`,
+					`matched : "id" (by pattern prefix: "id")`,
+				)
+			},
+		},
+
+		// MatchRegEx().WithGroup tests
+		{Scenario: "To(Match.WithGroup)/matches",
+			Act: func() {
+				Expect("user: id=42;").To(MatchRegEx(`id=(?P<id>\d+);`).WithGroup("id", Equal("42")))
+			},
+		},
+		{Scenario: "To(Match.WithGroup)/matches (numbered group)",
+			Act: func() {
+				Expect("user: id=42;").To(MatchRegEx(`id=(\d+);`).WithGroup("1", HavePrefix("4")))
+			},
+		},
+		{Scenario: "To(Match.WithGroup)/group does not match",
+			Act: func() {
+				Expect("user: id=41;").To(MatchRegEx(`id=(?P<id>\d+);`).WithGroup("id", Equal("42")))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: string containing match for: "id=(?P<id>\\d+);"`,
+					`got     : "user: id=41;"`,
+					`                    ^^`,
+					`group "id":`,
+					`  expected "42", got "41"`,
+				)
+			},
+		},
+		{Scenario: "To(Match.WithGroup)/second group does not match",
+			Act: func() {
+				Expect("a=1 b=2").To(MatchRegEx(`a=(?P<a>\d) b=(?P<b>\d)`).
+					WithGroup("a", Equal("1")).
+					WithGroup("b", Equal("3")),
+				)
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					`group "b":`,
+					`  expected "3", got "2"`,
+				)
+			},
+		},
+		{Scenario: "To(Match.WithGroup)/no such group",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("id=42").To(MatchRegEx(`id=(?P<id>\d+)`).WithGroup("name", Equal("42")))
+			},
+		},
+		{Scenario: "To(Match.WithGroup)/no matcher",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("id=42").To(MatchRegEx(`id=(?P<id>\d+)`).WithGroup("id", nil))
+			},
+		},
+		{Scenario: "ToNot(Match.WithGroup)/group does not match",
+			Act: func() {
+				Expect("id=41").ToNot(MatchRegEx(`id=(?P<id>\d+)`).WithGroup("id", Equal("42")))
+			},
+		},
+
+		// MatchRegExAll tests
+		{Scenario: "To(MatchRegExAll)/expected number of matches",
+			Act: func() { Expect("a1 b2 c3").To(MatchRegExAll(`[a-z]\d`, 3)) },
+		},
+		{Scenario: "To(MatchRegExAll)/no matches expected",
+			Act: func() { Expect("abc").To(MatchRegExAll(`\d`, 0)) },
+		},
+		{Scenario: "To(MatchRegExAll)/unexpected number of matches",
+			Act: func() { Expect("a1 b2 c3").To(MatchRegExAll(`[a-z]\d`, 2)) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: 2 matches for: "[a-z]\\d"`,
+					`got     : "a1 b2 c3"`,
+					`           ^^ ^^ ^^`,
+					`found   : 3 matches`,
+				)
+			},
+		},
+		{Scenario: "ToNot(MatchRegExAll)/expected number of matches",
+			Act: func() { Expect("a1").ToNot(MatchRegExAll(`[a-z]\d`, 1)) },
+			Assert: func(result *R) {
+				result.Expect(
+					`expected: not 1 match for: "[a-z]\\d"`,
+					`got     : "a1"`,
+					`           ^^`,
+					`found   : 1 match`,
+				)
+			},
+		},
+		{Scenario: "To(MatchRegExAll)/negative count",
+			Act: func() {
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+				Expect("abc").To(MatchRegExAll(`\d`, -1))
+			},
+		},
+
 		{Scenario: "matching with a custom type based on string",
 			Act: func() {
 				type myString string
@@ -132,3 +528,22 @@ func TestStringMatcher(t *testing.T) {
 		},
 	}...))
 }
+
+func TestEqualFoldMatch_OnTestFailure(t *testing.T) {
+	With(t)
+
+	Run(Test("difference identified using simple folding", func() {
+		// ARRANGE
+		sut := strings.EqualFoldMatch{Expected: "STOQ"}
+
+		// ACT
+		result := sut.OnTestFailure("ſtop")
+
+		// ASSERT
+		Expect(result).To(EqualSlice([]string{
+			`expected: string equal (ignoring case) to: "STOQ"`,
+			`got     : "ſtop"`,
+			`              ^`,
+		}))
+	}))
+}
@@ -0,0 +1,51 @@
+package strings
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blugnu/test/opt"
+)
+
+// SuffixMatch is a matcher that tests whether a string ends with
+// an expected suffix.
+type SuffixMatch struct {
+	Expected string
+}
+
+func (m SuffixMatch) Match(got string, opts ...any) bool {
+	return strings.HasSuffix(fold(got, opts...), fold(m.Expected, opts...))
+}
+
+func (m SuffixMatch) OnTestFailure(got string, opts ...any) []string {
+	var result []string
+
+	// offsets in the folded string are mapped to offsets in got
+	folded, offsets := foldOffsets(got, opts...)
+	expected := fold(m.Expected, opts...)
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		result = []string{
+			"expected: string not ending with: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+			markers(got, [][2]int{{offsets[len(folded)-len(expected)], len(got)}}, opts...),
+		}
+	} else {
+		// identify the last character that differs from the suffix
+		i := offsets[len(folded)-commonSuffixLen(folded, expected)]
+		if i > 0 {
+			_, n := utf8.DecodeLastRuneInString(got[:i])
+			i -= n
+		}
+		result = []string{
+			"expected: string ending with: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+			markers(got, [][2]int{{i, i}}, opts...),
+		}
+	}
+
+	if opt.IsSet(opts, opt.CaseSensitive(false)) {
+		result = append(result, caseInsensitive)
+	}
+	return result
+}
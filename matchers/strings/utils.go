@@ -0,0 +1,162 @@
+package strings
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blugnu/test/opt"
+)
+
+// reportIndent is the width of the labels used in test failure reports,
+// e.g. "got     : "; markers are indented by this amount to align with
+// the value being reported
+const reportIndent = "          "
+
+// caseInsensitive is a line appended to test failure reports when
+// strings were compared without regard to case
+const caseInsensitive = "(case insensitive comparison)"
+
+// fold returns the specified string in lower-case if the options
+// specify opt.CaseSensitive(false), otherwise the string is returned
+// unmodified.
+func fold(s string, opts ...any) string {
+	if opt.IsSet(opts, opt.CaseSensitive(false)) {
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// foldOffsets returns the specified string folded as for fold, with the
+// byte offset in the original string corresponding to each byte offset in the
+// folded string, including the offset of the end of the string.
+//
+// Folding may change the length (in bytes) of a string, so offsets in a folded
+// string must be mapped to the original string before being used to identify
+// substrings of the original string.
+func foldOffsets(s string, opts ...any) (string, []int) {
+	offsets := make([]int, 0, len(s)+1)
+
+	if !opt.IsSet(opts, opt.CaseSensitive(false)) {
+		for i := 0; i <= len(s); i++ {
+			offsets = append(offsets, i)
+		}
+		return s, offsets
+	}
+
+	sb := strings.Builder{}
+	for i, r := range s {
+		n, _ := sb.WriteString(strings.ToLower(string(r)))
+		for j := 0; j < n; j++ {
+			offsets = append(offsets, i)
+		}
+	}
+	offsets = append(offsets, len(s))
+
+	return sb.String(), offsets
+}
+
+// collapseWhitespace returns the specified string with leading and trailing
+// whitespace removed and any run of whitespace replaced by a single space.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// collapseOffsets returns the specified string with whitespace collapsed as
+// for collapseWhitespace, with the byte offset in the original string
+// corresponding to each byte offset in the collapsed string, including the
+// offset of the end of the string (the end of the last non-whitespace rune).
+func collapseOffsets(s string) (string, []int) {
+	sb := strings.Builder{}
+	offsets := make([]int, 0, len(s)+1)
+
+	end := 0
+	space := false
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			space = sb.Len() > 0
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			offsets = append(offsets, end)
+			space = false
+		}
+		n, _ := sb.WriteRune(r)
+		for j := 0; j < n; j++ {
+			offsets = append(offsets, i)
+		}
+		end = i + n
+	}
+	offsets = append(offsets, end)
+
+	return sb.String(), offsets
+}
+
+// commonPrefixLen returns the length (in bytes) of the longest common prefix
+// of two strings, respecting rune boundaries.
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) {
+		ra, na := utf8.DecodeRuneInString(a[i:])
+		rb, nb := utf8.DecodeRuneInString(b[i:])
+		if ra != rb || na != nb {
+			break
+		}
+		i += na
+	}
+	return i
+}
+
+// commonSuffixLen returns the length (in bytes) of the longest common suffix
+// of two strings, respecting rune boundaries.
+func commonSuffixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) {
+		ra, na := utf8.DecodeLastRuneInString(a[:len(a)-n])
+		rb, nb := utf8.DecodeLastRuneInString(b[:len(b)-n])
+		if ra != rb || na != nb {
+			break
+		}
+		n += na
+	}
+	return n
+}
+
+// column returns the column, relative to the start of a string formatted for
+// a test failure report, at which the byte at index i of the string will be
+// presented.  This takes into account any quoting of the string (and any
+// characters escaped as a result).
+func column(s string, i int, opts ...any) int {
+	if opt.IsSet(opts, opt.QuotedStrings(false)) {
+		return utf8.RuneCountInString(s[:i])
+	}
+
+	// the quoted substring includes a closing quote that is not present
+	// at this position in the quoted string as a whole
+	return utf8.RuneCountInString(strconv.Quote(s[:i])) - 1
+}
+
+// markers returns a line for a test failure report that places markers (^)
+// under the specified substrings of a string s, identified by their start and
+// end byte indices, i.e. [start, end).  A substring with start == end is marked
+// with a single marker.
+//
+// Substrings must be specified in order and must not overlap.
+func markers(s string, ranges [][2]int, opts ...any) string {
+	sb := strings.Builder{}
+	sb.WriteString(reportIndent)
+
+	col := 0
+	for _, r := range ranges {
+		start := column(s, r[0], opts...)
+		end := max(column(s, r[1], opts...), start+1)
+
+		sb.WriteString(strings.Repeat(" ", max(0, start-col)))
+		sb.WriteString(strings.Repeat("^", end-start))
+		col = end
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package strings
+
+import (
+	"github.com/blugnu/test/opt"
+)
+
+// EqualIgnoringWhitespaceMatch is a matcher that tests whether a string is
+// equal to an expected string when leading and trailing whitespace is ignored
+// and any runs of whitespace are collapsed to a single space.
+type EqualIgnoringWhitespaceMatch struct {
+	Expected string
+}
+
+func (m EqualIgnoringWhitespaceMatch) Match(got string, opts ...any) bool {
+	return fold(collapseWhitespace(got), opts...) == fold(collapseWhitespace(m.Expected), opts...)
+}
+
+func (m EqualIgnoringWhitespaceMatch) OnTestFailure(got string, opts ...any) []string {
+	var result []string
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		result = []string{
+			"expected: string not equal (ignoring whitespace) to: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+		}
+	} else {
+		// the difference is identified in the collapsed and folded strings;
+		// offsets in these are mapped back to offsets in got
+		exp := collapseWhitespace(m.Expected)
+		collapsed, collapsedOffsets := collapseOffsets(got)
+		folded, foldedOffsets := foldOffsets(collapsed, opts...)
+		i := collapsedOffsets[foldedOffsets[commonPrefixLen(folded, fold(exp, opts...))]]
+		result = []string{
+			"strings not equal (ignoring whitespace):",
+			"expected: " + opt.ValueAsString(m.Expected, opts...),
+			"got     : " + opt.ValueAsString(got, opts...),
+			markers(got, [][2]int{{i, i}}, opts...),
+		}
+	}
+
+	if opt.IsSet(opts, opt.CaseSensitive(false)) {
+		result = append(result, caseInsensitive)
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/blugnu/test/opt"
+)
+
+// AssignableMatcher is a matcher that tests whether a value is assignable
+// to a type T.  If T is an interface type, this is a test that the value
+// implements that interface.
+type AssignableMatcher[T any] struct{}
+
+// target returns the reflect.Type of T; this is obtained from a pointer to T
+// so that interface types are correctly identified.
+func (AssignableMatcher[T]) target() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (m AssignableMatcher[T]) Match(got any, _ ...any) bool {
+	if got == nil {
+		return false
+	}
+	return reflect.TypeOf(got).AssignableTo(m.target())
+}
+
+func (m AssignableMatcher[T]) OnTestFailure(got any, opts ...any) []string {
+	target := m.target()
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			"expected: value not assignable to: " + target.String(),
+			"got     : " + typeName(got),
+		}
+	}
+
+	result := []string{
+		"expected: value assignable to: " + target.String(),
+		"got     : " + typeName(got),
+	}
+
+	if got == nil || target.Kind() != reflect.Interface {
+		return result
+	}
+
+	gotType := reflect.TypeOf(got)
+	if missing := missingMethods(gotType, target); len(missing) > 0 {
+		result = append(result, "missing : "+strings.Join(missing, ", "))
+	}
+
+	// a common mistake is to return a value where the methods of the
+	// interface are implemented with pointer receivers
+	if gotType.Kind() != reflect.Pointer && reflect.PointerTo(gotType).Implements(target) {
+		result = append(result, fmt.Sprintf("note    : *%s implements %s (methods have pointer receivers)", gotType, target))
+	}
+
+	return result
+}
+
+// missingMethods returns the names of the methods of an interface that are
+// not implemented by a specified type, in the order in which they are
+// declared by the interface.  A method that is present but with a different
+// signature is identified as such.
+func missingMethods(t, iface reflect.Type) []string {
+	result := []string{}
+	for i := 0; i < iface.NumMethod(); i++ {
+		im := iface.Method(i)
+
+		m, ok := t.MethodByName(im.Name)
+		switch {
+		case !ok:
+			result = append(result, im.Name)
+		case !methodSignatureMatches(m, im, t.Kind() == reflect.Interface):
+			result = append(result, im.Name+" (wrong signature)")
+		}
+	}
+	return result
+}
+
+// methodSignatureMatches returns true if a method of a type has the same
+// signature as a method of an interface.  For a method of a concrete type
+// the method type includes the receiver as the first argument, which is
+// ignored.
+func methodSignatureMatches(m, im reflect.Method, isInterface bool) bool {
+	mt, it := m.Type, im.Type
+
+	skip := 1
+	if isInterface {
+		skip = 0
+	}
+
+	if mt.NumIn()-skip != it.NumIn() || mt.NumOut() != it.NumOut() || mt.IsVariadic() != it.IsVariadic() {
+		return false
+	}
+	for i := 0; i < it.NumIn(); i++ {
+		if mt.In(i+skip) != it.In(i) {
+			return false
+		}
+	}
+	for i := 0; i < it.NumOut(); i++ {
+		if mt.Out(i) != it.Out(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// typeName returns the name of the type of a value, or "nil" if the value
+// is nil (an untyped nil has no type).
+func typeName(v any) string {
+	if v == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(v).String()
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"reflect"
+
+	"github.com/blugnu/test/opt"
+)
+
+// KindMatcher is a matcher that tests whether a value is of a specified
+// reflect.Kind.  A nil value is of kind reflect.Invalid.
+type KindMatcher struct {
+	Expected reflect.Kind
+}
+
+// kindOf returns the reflect.Kind of a value
+func kindOf(v any) reflect.Kind {
+	return reflect.ValueOf(v).Kind()
+}
+
+func (m KindMatcher) Match(got any, _ ...any) bool {
+	return kindOf(got) == m.Expected
+}
+
+func (m KindMatcher) OnTestFailure(got any, opts ...any) []string {
+	gotKind := kindOf(got).String()
+	if got != nil {
+		gotKind += " (" + typeName(got) + ")"
+	}
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			"expected: value not of kind: " + m.Expected.String(),
+			"got     : " + gotKind,
+		}
+	}
+
+	return []string{
+		"expected: value of kind: " + m.Expected.String(),
+		"got     : " + gotKind,
+	}
+}
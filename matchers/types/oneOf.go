@@ -0,0 +1,55 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/blugnu/test/opt"
+)
+
+// OneOfMatcher is a matcher that tests whether a value is of any one of
+// a set of types.  A concrete type is matched only by a value of exactly
+// that type; an interface type is matched by any value that implements
+// the interface.
+type OneOfMatcher struct {
+	Expected []reflect.Type
+}
+
+// Matched returns the first of the expected types matched by a value, or
+// nil if the value does not match any of them.
+func (m OneOfMatcher) Matched(got any) reflect.Type {
+	if got == nil {
+		return nil
+	}
+
+	gotType := reflect.TypeOf(got)
+	for _, t := range m.Expected {
+		if t == gotType || (t.Kind() == reflect.Interface && gotType.Implements(t)) {
+			return t
+		}
+	}
+	return nil
+}
+
+func (m OneOfMatcher) Match(got any, _ ...any) bool {
+	return m.Matched(got) != nil
+}
+
+func (m OneOfMatcher) OnTestFailure(got any, opts ...any) []string {
+	names := make([]string, len(m.Expected))
+	for i, t := range m.Expected {
+		names[i] = t.String()
+	}
+
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		return []string{
+			"expected: value of type other than: " + strings.Join(names, ", "),
+			"got     : " + typeName(got),
+		}
+	}
+
+	return []string{
+		"expected: value of type one of: " + strings.Join(names, ", "),
+		"got     : " + typeName(got),
+	}
+}
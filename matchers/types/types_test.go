@@ -0,0 +1,112 @@
+package types_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	. "github.com/blugnu/test"
+)
+
+type counter struct{ n int }
+
+func (c *counter) String() string { return fmt.Sprintf("%d", c.n) }
+
+type badStringer struct{}
+
+func (badStringer) String(int) string { return "" }
+
+func TestBeAssignableTo(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "concrete type assignable to same type",
+			Act: func() { Expect(any(42)).Should(BeAssignableTo[int]()) },
+		},
+		{Scenario: "value implementing interface",
+			Act: func() { Expect(any(&bytes.Buffer{})).Should(BeAssignableTo[io.Reader]()) },
+		},
+		{Scenario: "value not implementing interface",
+			Act: func() { Expect(any(&bytes.Buffer{})).Should(BeAssignableTo[io.ReadCloser]()) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: io.ReadCloser",
+					"got     : *bytes.Buffer",
+					"missing : Close",
+				)
+			},
+		},
+		{Scenario: "method with wrong signature",
+			Act: func() { Expect(any(badStringer{})).Should(BeAssignableTo[fmt.Stringer]()) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: fmt.Stringer",
+					"got     : types_test.badStringer",
+					"missing : String (wrong signature)",
+				)
+			},
+		},
+		{Scenario: "methods with pointer receivers",
+			Act: func() { Expect(any(counter{})).Should(BeAssignableTo[fmt.Stringer]()) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: fmt.Stringer",
+					"got     : types_test.counter",
+					"missing : String",
+					"note    : *types_test.counter implements fmt.Stringer (methods have pointer receivers)",
+				)
+			},
+		},
+		{Scenario: "nil",
+			Act: func() { Expect(any(nil)).Should(BeAssignableTo[fmt.Stringer]()) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: fmt.Stringer",
+					"got     : nil",
+				)
+			},
+		},
+		{Scenario: "not assignable when assignable",
+			Act: func() { Expect(any("s")).ShouldNot(BeAssignableTo[string]()) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value not assignable to: string",
+					"got     : string",
+				)
+			},
+		},
+	}...))
+}
+
+func TestHaveKind(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "struct is struct",
+			Act: func() { Expect(any(counter{})).Should(HaveKind(reflect.Struct)) },
+		},
+		{Scenario: "pointer is not struct",
+			Act: func() { Expect(any(&counter{})).Should(HaveKind(reflect.Struct)) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value of kind: struct",
+					"got     : ptr (*types_test.counter)",
+				)
+			},
+		},
+		{Scenario: "nil is invalid",
+			Act: func() { Expect(any(nil)).Should(HaveKind(reflect.Invalid)) },
+		},
+		{Scenario: "not slice when slice",
+			Act: func() { Expect(any([]int{})).ShouldNot(HaveKind(reflect.Slice)) },
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value not of kind: slice",
+					"got     : slice ([]int)",
+				)
+			},
+		},
+	}...))
+}
@@ -3,6 +3,14 @@ package test
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blugnu/test/matchers/matcher"
+	"github.com/blugnu/test/matchers/mocks"
 )
 
 // MockFn is a generic type that can be used to mock a function returning some result
@@ -17,12 +25,15 @@ import (
 //     for the corresponding expected call.
 //
 //   - Mapped Results: results for a given set of arguments are configured using the
-//     WhenCalledWith method.  In this mode, the mock will fail to meet expectations if
-//     all configured argument:result combinations are not used.
+//     WhenCalledWith method, with an optional default result for any other arguments
+//     configured using WhenCalledWithAnything (or Otherwise).  In this mode, the mock
+//     will fail to meet expectations if all configured argument:result combinations
+//     are not used.
 //
 // An implementation of the function being mocked must be provided by the test code to
 // record calls to the mock function and to return the configured result and/or error
-// using either ExpectedResult (expected calls) or ResultFor (mapped results) methods.
+// using either the RecordCall or ResultFor methods; calls are recorded in the same way
+// by both methods, in either mode.
 //
 // The type can be used in a variety of ways to suit the requirements of the mock in a
 // particular test scenario.
@@ -61,6 +72,10 @@ import (
 // (in addition to an error), a struct type may be used for the R type parameter,
 // with fields for each of the result values.
 //
+// The A type need not be comparable; arguments of a comparable type are compared using
+// the == operator, otherwise using reflect.DeepEqual.  This allows functions accepting
+// slices, maps or structs containing them to be mocked.
+//
 // When mocking a method which accepts no arguments, or if not interested in the
 // arguments, then you may use type any for the A type parameter, or consider
 // using the simpler test.Fake[R] type instead.
@@ -69,6 +84,13 @@ import (
 // simply ignore the Err field.  To fake a method that returns only an error, specify
 // a result type of any and ignore the Result field.
 //
+// # Concurrency
+//
+// A MockFn may be called concurrently, e.g. by code under test that calls a mocked
+// dependency from a pool of workers; RecordCall, ResultFor, ExpectationsWereMet,
+// RecordedCalls and Reset are safe for concurrent use.  Expected calls and mapped
+// results should be configured before the code under test is run.
+//
 // # Example
 //
 //	type MyInterface interface {
@@ -88,7 +110,10 @@ import (
 //	func (mock *myMock) MyMethodWithArgs(id string, opt bool) (int, error) {
 //		return mock.myMethodWithArgs.CalledWith(struct{ID string; Opt bool}{ID: id, Opt: opt})
 //	}
-type MockFn[A comparable, R any] struct {
+type MockFn[A any, R any] struct {
+	// mu guards the state of the mock function, which may be called concurrently
+	mu sync.Mutex
+
 	// actual is a slice of all calls made to the mock function by the code under test
 	actual []*mockFnCall[A, R]
 
@@ -105,18 +130,39 @@ type MockFn[A comparable, R any] struct {
 	// recorded
 	idxExpected int
 
-	// responses is a map of arguments to the Fake[R] values configured for those arguments;
-	// the map is nil if the mock function is not configured for mapped results
-	responses map[A]*FakeResult[R]
+	// responses is a slice of the FakeResult[R] values configured for specific arguments;
+	// the slice is nil if the mock function is not configured for mapped results.
+	//
+	// A slice is used rather than a map since arguments need not be comparable.
+	responses []*mockFnResponse[A, R]
+
+	// otherwise is the result for arguments for which no result is configured in mapped
+	// results mode (if any), configured using WhenCalledWithAnything or Otherwise
+	otherwise *FakeResult[R]
+
+	// lenient is true if a call in mapped results mode with arguments for which no result
+	// is configured returns zero values rather than panicking
+	lenient bool
 
 	// errs is a slice of errors recorded during the test; the slice is nil if no errors have
 	// been recorded
 	errs []error
+
+	// unordered is true if expected calls may be made in any order
+	unordered bool
+
+	// seq is the sequence of which the mock function is a step (if any), with seqIdx the
+	// index of that step in the sequence
+	seq    *Sequence
+	seqIdx int
+
+	// captor captures the arguments of all calls to the mock function (if any)
+	captor *Captor[A]
 }
 
 // mockFnCall represents a call to a mock function.  It is used both to configure expected
 // calls and to record actual calls.
-type mockFnCall[A comparable, R any] struct {
+type mockFnCall[A any, R any] struct {
 	// args is a pointer to the arguments associated with the call.
 	//
 	// - For an expected call: the value is nil if the call does not have any arguments or
@@ -126,6 +172,11 @@ type mockFnCall[A comparable, R any] struct {
 	//   not recorded.
 	args *A
 
+	// matcher is a matcher to be satisfied by the arguments recorded for an expected
+	// call; the value is nil if arguments are not significant or are configured using
+	// WithArgs.  Not used for recorded calls.
+	matcher matcher.ForType[A]
+
 	// result is the result associated with the call.
 	//
 	// - For an expected call: the value(s) to be returned as the result when the expected call
@@ -140,6 +191,55 @@ type mockFnCall[A comparable, R any] struct {
 	//
 	// - For a recorded call: any error determined when the call is recorded.
 	err error
+
+	// times is the number of calls expected for an expected call; the value is nil if
+	// exactly one call is expected.  Not used for recorded calls.
+	times *callCount
+
+	// behaviour is any additional behaviour of an expected call (computing the result,
+	// delaying, blocking or panicking); nil if the call simply returns the configured
+	// result and error.  Not used for recorded calls.
+	behaviour *behaviour
+
+	// calls is the number of actual calls that have been matched to an expected call.
+	// Not used for recorded calls.
+	calls int
+
+	// seq is the sequence of which the expected call is a step (if any), with seqIdx the
+	// index of that step in the sequence.  Not used for recorded calls.
+	seq    *Sequence
+	seqIdx int
+
+	// captor captures the arguments of calls matched with an expected call (if any).
+	// Not used for recorded calls.
+	captor *Captor[A]
+}
+
+// callCount is the number of calls expected for an expected call to a mock function,
+// expressed as a minimum and maximum; a maximum of -1 indicates no upper limit.
+type callCount struct {
+	min, max int
+	desc     string
+}
+
+// mockFnResponse is a result configured for specific arguments to a mock function
+// using WhenCalledWith.
+type mockFnResponse[A any, R any] struct {
+	args   A
+	result *FakeResult[R]
+}
+
+// argsEqual returns true if two sets of arguments are equal.  Comparable values
+// are compared using ==, otherwise using reflect.DeepEqual.
+//
+// Comparability is determined from the values rather than the type, since an
+// interface type is comparable but may hold a value that is not.
+func argsEqual[A any](a, b A) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Comparable() && vb.Comparable() && va.Type() == vb.Type() {
+		return va.Equal(vb)
+	}
+	return reflect.DeepEqual(a, b)
 }
 
 // WillReturn configures the result and/or error to be returned by the mock function for an
@@ -182,13 +282,64 @@ func (mock *mockFnCall[A, R]) WillReturn(v ...any) {
 	}
 }
 
+// WillCall configures a function to be called to compute the result and error to be
+// returned by the mock function for an expected call, from the arguments of the call
+// (the zero value of A if no arguments are recorded).
+//
+// The function is not called if the call does not meet expectations (e.g. the arguments
+// do not match those expected); the mock function returns the configured result and the
+// error identifying the unmet expectation.  Any result and error configured using
+// WillReturn are otherwise replaced by those returned by the function.
+func (mock *mockFnCall[A, R]) WillCall(fn func(A) (R, error)) {
+	if fn == nil {
+		panic(fmt.Errorf("%w: WillCall: a function must be specified", ErrInvalidArgument))
+	}
+	mock.ensureBehaviour().fn = fn
+}
+
+// WillPanic configures an expected call to panic with a specified value when made,
+// simulating a crashing dependency.
+func (mock *mockFnCall[A, R]) WillPanic(v any) {
+	b := mock.ensureBehaviour()
+	b.panics = true
+	b.panicValue = v
+}
+
+// WillDelay configures an expected call to wait for a specified duration before
+// returning, simulating a slow dependency.
+func (mock *mockFnCall[A, R]) WillDelay(d time.Duration) *mockFnCall[A, R] {
+	mock.ensureBehaviour().delay = d
+	return mock
+}
+
+// WillBlockUntil configures an expected call to block until a context is done or a
+// channel is closed (or receives a value), simulating a hung dependency.  The argument
+// must be a context.Context, a chan struct{} or a <-chan struct{}; any other value,
+// a nil channel or a context that can never be done (e.g. context.Background())
+// causes a panic with ErrInvalidArgument.
+//
+// Once unblocked, the call returns the configured result and error.
+func (mock *mockFnCall[A, R]) WillBlockUntil(v any) *mockFnCall[A, R] {
+	mock.ensureBehaviour().until = blockUntil("WillBlockUntil", v)
+	return mock
+}
+
+// ensureBehaviour returns the behaviour of an expected call, initialising it
+// if required
+func (mock *mockFnCall[A, R]) ensureBehaviour() *behaviour {
+	if mock.behaviour == nil {
+		mock.behaviour = &behaviour{}
+	}
+	return mock.behaviour
+}
+
 // WithArgs configures the arguments associated with an expected call to the mock function.
 //
 // The method accepts a single argument of type A, which is used to configure the arguments
 // for the expected call.  If args are already configured for the mock function, the function
 // will panic with an ErrInvalidOperation error.
 func (mock *mockFnCall[A, R]) WithArgs(args A) *mockFnCall[A, R] {
-	if mock.args != nil {
+	if mock.args != nil || mock.matcher != nil {
 		panic(fmt.Errorf("%w: arguments already configured", ErrInvalidOperation))
 	}
 
@@ -196,6 +347,185 @@ func (mock *mockFnCall[A, R]) WithArgs(args A) *mockFnCall[A, R] {
 	return mock
 }
 
+// Capture attaches a Captor to an expected call, capturing the arguments of calls
+// matched with the expected call (whether or not the arguments meet expectations),
+// e.g. to test arguments that cannot be known in advance:
+//
+//	var c Captor[Event]
+//	mock.ExpectCall().WithArgsMatching(AnyArgs[Event]()).Capture(&c)
+//
+// If c is nil, Capture panics with ErrInvalidArgument; if a Captor is already
+// attached to the expected call, Capture panics with ErrInvalidOperation.
+func (mock *mockFnCall[A, R]) Capture(c *Captor[A]) *mockFnCall[A, R] {
+	switch {
+	case c == nil:
+		panic(fmt.Errorf("%w: a captor must be specified", ErrInvalidArgument))
+	case mock.captor != nil:
+		panic(fmt.Errorf("%w: captor already attached", ErrInvalidOperation))
+	}
+
+	mock.captor = c
+	return mock
+}
+
+// WithArgsMatching configures a matcher to be satisfied by the arguments of an expected
+// call to the mock function, e.g.:
+//
+//	mock.ExpectCall().WithArgsMatching(ContainString("needle"))
+//
+// If the arguments recorded for the call do not satisfy the matcher, the call is recorded
+// with ErrUnexpectedArgs and the test failure report of the matcher.
+//
+// Use AnyArgs() to configure an expected call for which arguments must be recorded but
+// where the values of those arguments are not significant.
+//
+// If args are already configured for the expected call, the function will panic with an
+// ErrInvalidOperation error.
+func (mock *mockFnCall[A, R]) WithArgsMatching(m matcher.ForType[A]) *mockFnCall[A, R] {
+	switch {
+	case m == nil:
+		panic(fmt.Errorf("%w: a matcher must be specified", ErrInvalidArgument))
+	case mock.args != nil || mock.matcher != nil:
+		panic(fmt.Errorf("%w: arguments already configured", ErrInvalidOperation))
+	}
+
+	mock.matcher = m
+	return mock
+}
+
+// AnyArgs returns a matcher for use with WithArgsMatching that is satisfied by any
+// arguments.  An expected call configured with AnyArgs requires that arguments are
+// recorded for the call, but the values of those arguments are not significant.
+//
+// By contrast, an expected call with no arguments configured is satisfied by a call
+// whether or not arguments are recorded.
+func AnyArgs[A any]() mocks.AnyArgsMatcher[A] {
+	return mocks.AnyArgsMatcher[A]{}
+}
+
+// setTimes configures the number of calls expected for an expected call,
+// panicking if the number of calls has already been configured.
+func (mock *mockFnCall[A, R]) setTimes(fn string, n int, c callCount) *mockFnCall[A, R] {
+	switch {
+	case n < 0:
+		panic(fmt.Errorf("%w: %s: number of calls must be >= 0", ErrInvalidArgument, fn))
+	case mock.times != nil:
+		panic(fmt.Errorf("%w: %s: number of calls already configured", ErrInvalidOperation, fn))
+	}
+
+	mock.times = &c
+	return mock
+}
+
+// Times configures an expected call to be made exactly n times.
+func (mock *mockFnCall[A, R]) Times(n int) *mockFnCall[A, R] {
+	return mock.setTimes("Times", n, callCount{n, n, plural(n, "call")})
+}
+
+// AtLeast configures an expected call to be made at least n times.
+func (mock *mockFnCall[A, R]) AtLeast(n int) *mockFnCall[A, R] {
+	return mock.setTimes("AtLeast", n, callCount{n, -1, "at least " + plural(n, "call")})
+}
+
+// AtMost configures an expected call to be made no more than n times.
+func (mock *mockFnCall[A, R]) AtMost(n int) *mockFnCall[A, R] {
+	return mock.setTimes("AtMost", n, callCount{0, n, "at most " + plural(n, "call")})
+}
+
+// AnyTimes configures an expected call that may be made any number of times,
+// including not at all.
+func (mock *mockFnCall[A, R]) AnyTimes() *mockFnCall[A, R] {
+	return mock.setTimes("AnyTimes", 0, callCount{0, -1, "any number of calls"})
+}
+
+// Never configures an expected call that must not be made.  Unlike other expected
+// calls, a call that must never be made applies regardless of the order in which
+// expected calls are configured: any call to the mock function with matching
+// arguments (or any call at all, if no arguments are configured) is unexpected.
+func (mock *mockFnCall[A, R]) Never() *mockFnCall[A, R] {
+	return mock.setTimes("Never", 0, callCount{0, 0, "no calls"})
+}
+
+// bounds returns the minimum and maximum number of calls expected for an expected
+// call; a maximum of -1 indicates no upper limit.
+func (mock *mockFnCall[A, R]) bounds() (int, int) {
+	if mock.times == nil {
+		return 1, 1
+	}
+	return mock.times.min, mock.times.max
+}
+
+// isNever returns true if the expected call must never be made
+func (mock *mockFnCall[A, R]) isNever() bool {
+	_, max := mock.bounds()
+	return max == 0
+}
+
+// isSatisfied returns true if the expected call has been made at least the
+// minimum number of times expected
+func (mock *mockFnCall[A, R]) isSatisfied() bool {
+	min, _ := mock.bounds()
+	return mock.calls >= min
+}
+
+// isExhausted returns true if the expected call has been made the maximum number
+// of times expected
+func (mock *mockFnCall[A, R]) isExhausted() bool {
+	_, max := mock.bounds()
+	return max != -1 && mock.calls >= max
+}
+
+// argsMatch returns true if the arguments of an actual call satisfy any arguments
+// configured for an expected call
+func (mock *mockFnCall[A, R]) argsMatch(args *A) bool {
+	switch {
+	case mock.args == nil && mock.matcher == nil:
+		return true
+	case args == nil:
+		return false
+	case mock.matcher != nil:
+		return mock.matcher.Match(*args)
+	default:
+		return argsEqual(*mock.args, *args)
+	}
+}
+
+// describe returns a description of an expected call in terms of the number of
+// calls and any arguments expected, and the number of calls actually made, e.g.
+// "expected 3 calls with args 42, got 1"
+func (mock *mockFnCall[A, R]) describe() string {
+	expected := "1 call"
+	if mock.times != nil {
+		expected = mock.times.desc
+	}
+
+	switch {
+	case mock.matcher != nil:
+		expected += fmt.Sprintf(" with args matching %T", mock.matcher)
+	case mock.args != nil:
+		expected += fmt.Sprintf(" with args %v", *mock.args)
+	}
+
+	return fmt.Sprintf("expected %s, got %d", expected, mock.calls)
+}
+
+// plural returns a count of some noun, pluralised if the count is not 1,
+// e.g. "1 call", "3 calls"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// argsReport returns the test failure report of the matcher configured for an
+// expected call, for the arguments recorded by an actual call, as a string
+// indented for inclusion in an error.
+func (mock *mockFnCall[A, R]) argsReport(got A) string {
+	report := matcher.Report(mock.matcher, got)
+	return "  " + strings.Join(report, "\n  ")
+}
+
 // RecordCall is used by a mock implementation to record a call to a mock function,
 // optionally testing that arguments match those expected and returning the result and
 // error configured for the expected call.
@@ -208,6 +538,9 @@ func (mock *mockFnCall[A, R]) WithArgs(args A) *mockFnCall[A, R] {
 // If the arguments do not match the expected call, mock.expected.Result is
 // returned with ErrUnexpectedArgs.
 //
+// If the mock function is configured for mapped results, the result and error
+// configured for the arguments are returned (see ResultFor).
+//
 // # errors
 //
 //	ErrUnexpectedCall       the call has no corresponding expected call
@@ -241,46 +574,246 @@ func (mock *mockFnCall[A, R]) WithArgs(args A) *mockFnCall[A, R] {
 //		return result.Result, result.Remainder, err
 //	}
 func (mock *MockFn[A, R]) RecordCall(args ...A) (R, error) {
-	if mock.responses != nil {
-		panic(fmt.Errorf("%w: mock function is configured for mapped results; use <fn>.ResultFor()", ErrInvalidOperation))
+	expected, fake, met, result, err := mock.recordCall(args)
+	switch {
+	case fake != nil:
+		return mock.mappedResult(fake, args)
+	case expected == nil || expected.behaviour == nil:
+		return result, err
 	}
 
+	// any behaviour of the expected call is applied without holding the lock on the
+	// mock, so that a call that is delayed or blocked does not block other calls
+	var a A
+	if len(args) > 0 {
+		a = args[0]
+	}
+	return applyBehaviour(expected.behaviour, met, a, result, err)
+}
+
+// recordCall records a call to the mock function.  This is the recording path for calls
+// in either mode, whether made using RecordCall or ResultFor.
+//
+// For a mock function configured for expected calls, it returns the expected call matched
+// with the call (if any), whether the call met expectations, and the result and error
+// to be returned.
+//
+// For a mock function configured for mapped results, it returns the FakeResult for the
+// arguments of the call (if any), from which the result and error are to be obtained;
+// if there is no FakeResult, the zero result is returned (with any error).
+func (mock *MockFn[A, R]) recordCall(args []A) (*mockFnCall[A, R], *FakeResult[R], bool, R, error) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
 	actual := &mockFnCall[A, R]{}
 	if len(args) > 0 {
 		actual.args = &args[0]
 	}
+	// a call for which there is no mapped result panics (unless the mock
+	// function is lenient) and is not recorded
+	if mock.responses != nil {
+		fake := mock.mappedCall(actual)
+		mock.actual = append(mock.actual, actual)
+		mock.captor.capture(actual.args)
 
-	if mock.expected == nil {
+		// a mock function with mapped results that is a step in a sequence is
+		// complete once any call has been made
+		if err := mock.recordStep(); err != nil && actual.err == nil {
+			actual.err = ErrOutOfOrder
+			mock.errs = append(mock.errs, err)
+			return nil, nil, false, *new(R), err
+		}
+		return nil, fake, actual.err == nil, *new(R), nil
+	}
+
+	mock.actual = append(mock.actual, actual)
+	mock.captor.capture(actual.args)
+
+	// a call matching an expectation that it is never made is unexpected,
+	// regardless of any other expectations
+	for _, ex := range mock.expectations {
+		if ex.isNever() && ex.argsMatch(actual.args) {
+			ex.calls++
+			actual.err = ErrUnexpectedCall
+			err := fmt.Errorf("%w: %s", ErrUnexpectedCall, ex.describe())
+			mock.errs = append(mock.errs, err)
+			return nil, nil, false, *new(R), err
+		}
+	}
+
+	var expected *mockFnCall[A, R]
+	if mock.unordered {
+		expected = mock.anyExpected(actual.args)
+	} else {
+		expected = mock.nextExpected(actual.args)
+	}
+
+	if expected == nil {
 		actual.err = ErrUnexpectedCall
 		err := fmt.Errorf("%w: with args: %v", ErrUnexpectedCall, args)
-		mock.actual = append(mock.actual, actual)
+
+		// if the call matches an expected call that has already been made the maximum
+		// number of times, report the expected number of calls
+		for _, ex := range mock.expectations {
+			if ex.times != nil && ex.isExhausted() && ex.argsMatch(actual.args) {
+				ex.calls++
+				err = fmt.Errorf("%w: %s", ErrUnexpectedCall, ex.describe())
+				break
+			}
+		}
+
 		mock.errs = append(mock.errs, err)
-		return *new(R), err
+
+		// a mock function with no expected calls that is a step in a sequence is
+		// complete once any call has been made; the call is nonetheless unexpected
+		if len(mock.expectations) == 0 {
+			_ = mock.recordStep()
+		}
+		return nil, nil, false, *new(R), err
 	}
 
 	// initially assume we will return the expected error; this may change once
 	// we evaluate arguments against expectations
-	err := mock.expected.err
+	err := expected.err
 
 	switch {
-	case mock.expected.args == nil:
+	case expected.args == nil && expected.matcher == nil:
 		// NO-OP - no arguments of interest were configured for the expected call
 
+	case actual.args == nil && expected.matcher != nil:
+		actual.err = ErrExpectedArgs
+		err = fmt.Errorf("%w:\n  expected: args matching: %T\n  got     : nil (no args recorded)", ErrExpectedArgs, expected.matcher)
+		mock.errs = append(mock.errs, err)
+
 	case actual.args == nil:
 		actual.err = ErrExpectedArgs
-		err = fmt.Errorf("%w:\n  expected: %v\n  got     : nil (no args recorded)", ErrExpectedArgs, mock.expected.args)
+		err = fmt.Errorf("%w:\n  expected: %v\n  got     : nil (no args recorded)", ErrExpectedArgs, *expected.args)
 		mock.errs = append(mock.errs, err)
 
-	case *mock.expected.args != *actual.args:
+	case expected.matcher != nil:
+		if !expected.matcher.Match(*actual.args) {
+			actual.err = ErrUnexpectedArgs
+			err = fmt.Errorf("%w:\n%s", ErrUnexpectedArgs, expected.argsReport(*actual.args))
+			mock.errs = append(mock.errs, err)
+		}
+
+	case !argsEqual(*expected.args, *actual.args):
 		actual.err = ErrUnexpectedArgs
-		err = fmt.Errorf("%w:\n  expected: %v\n  got     : %v", ErrUnexpectedArgs, mock.expected.args, actual.args)
+		err = fmt.Errorf("%w:\n  expected: %v\n  got     : %v", ErrUnexpectedArgs, *expected.args, *actual.args)
 		mock.errs = append(mock.errs, err)
 	}
 
-	// save the expected result as we are about to nil expected
-	result := mock.expected.result
+	expected.calls++
+	expected.captor.capture(actual.args)
+	if expected.isExhausted() && !mock.unordered {
+		mock.advance()
+	}
 
-	mock.actual = append(mock.actual, actual)
+	// the call must not be made before any preceding steps in a sequence of which
+	// the expected call or the mock function is a step
+	seqErr := expected.seq.record(expected.seqIdx, expected.isSatisfied(), expected.describe())
+	if e := mock.recordStep(); seqErr == nil {
+		seqErr = e
+	}
+	if seqErr != nil && actual.err == nil {
+		actual.err = ErrOutOfOrder
+		err = seqErr
+		mock.errs = append(mock.errs, err)
+	}
+
+	return expected, nil, actual.err == nil, expected.result, err
+}
+
+// mappedCall returns the FakeResult for an actual call to a mock function configured
+// for mapped results: the result configured for the arguments of the call, or the
+// result configured for any other arguments.
+//
+// If there is no such result and the mock function is lenient, the call is recorded as
+// unexpected and nil is returned; otherwise mappedCall panics with ErrNoResultForArgs,
+// before the call is recorded.
+func (mock *MockFn[A, R]) mappedCall(actual *mockFnCall[A, R]) *FakeResult[R] {
+	if actual.args != nil {
+		if r := mock.response(*actual.args); r != nil {
+			return r.result
+		}
+	}
+
+	switch {
+	case mock.otherwise != nil:
+		return mock.otherwise
+
+	case mock.lenient:
+		actual.err = ErrUnexpectedCall
+		if actual.args == nil {
+			mock.errs = append(mock.errs, fmt.Errorf("%w: no args recorded", ErrUnexpectedCall))
+		} else {
+			mock.errs = append(mock.errs, fmt.Errorf("%w: with args: %v", ErrUnexpectedCall, *actual.args))
+		}
+		return nil
+	}
+
+	panic(ErrNoResultForArgs)
+}
+
+// mappedResult returns the result and error of a FakeResult for a call to a mock
+// function configured for mapped results.  The result is obtained without holding
+// the lock on the mock, since the fake may be configured to delay or block.
+//
+// A call made once a sequence of results configured with FailWhenExhausted has been
+// exhausted is recorded with ErrResultsExhausted.
+func (mock *MockFn[A, R]) mappedResult(fake *FakeResult[R], args []A) (R, error) {
+	result, err := fake.Get()
+	if errors.Is(err, ErrResultsExhausted) {
+		mock.mu.Lock()
+		mock.errs = append(mock.errs, fmt.Errorf("%w: with args: %v", err, args))
+		mock.mu.Unlock()
+	}
+	return result, err
+}
+
+// anyExpected returns an expected call to be matched with an actual call having
+// specified arguments when expected calls may be made in any order, or nil if no
+// expected call matches the arguments.
+//
+// An expected call that has not yet been made the minimum number of times expected
+// is preferred over one that has (but which may be made further times).
+func (mock *MockFn[A, R]) anyExpected(args *A) *mockFnCall[A, R] {
+	var result *mockFnCall[A, R]
+	for _, ex := range mock.expectations {
+		if ex.isNever() || ex.isExhausted() || !ex.argsMatch(args) {
+			continue
+		}
+		if !ex.isSatisfied() {
+			return ex
+		}
+		if result == nil {
+			result = ex
+		}
+	}
+	return result
+}
+
+// nextExpected returns the expected call to be matched with an actual call having
+// specified arguments, or nil if there are no further expected calls.
+//
+// Expected calls are matched in the order they were configured.  An expected call
+// that has been made the minimum number of times expected is passed over if the
+// arguments do not match, so that the call may be matched with a subsequent
+// expected call.  Expected calls that are never to be made are always passed over.
+func (mock *MockFn[A, R]) nextExpected(args *A) *mockFnCall[A, R] {
+	for mock.expected != nil {
+		ex := mock.expected
+		if !ex.isNever() && (!ex.isSatisfied() || ex.argsMatch(args)) {
+			return ex
+		}
+		mock.advance()
+	}
+	return nil
+}
+
+// advance moves to the next expected call; if there are no further expected
+// calls, expected is set nil and idxExpected -1.
+func (mock *MockFn[A, R]) advance() {
 	mock.idxExpected++
 	mock.expected = nil
 
@@ -289,8 +822,6 @@ func (mock *MockFn[A, R]) RecordCall(args ...A) (R, error) {
 	} else {
 		mock.idxExpected = -1
 	}
-
-	return result, err
 }
 
 // ExpectedResults returns an error if any expectations were not met; otherwise nil.
@@ -306,29 +837,39 @@ func (mock *MockFn[A, R]) RecordCall(args ...A) (R, error) {
 //	ErrExpectationsNotMet      // one or more expectations were not met; the error is
 //	                           // joined with errors for each unmet expectation
 func (mock *MockFn[A, R]) ExpectationsWereMet() error {
-	if mock.responses != nil {
-		mock.errs = nil
-		args := map[A]struct{}{}
-		for arg := range mock.responses {
-			args[arg] = struct{}{}
-		}
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	// errors recorded with calls are copied so that unmet expectations
+	// identified here are not themselves recorded
+	errs := slices.Clone(mock.errs)
+
+responses:
+	for _, r := range mock.responses {
 		for _, called := range mock.actual {
-			delete(args, *called.args)
-		}
-		if len(args) > 0 {
-			for arg := range args {
-				mock.errs = append(mock.errs, fmt.Errorf("%w: %v", ErrResultNotUsed, arg))
+			if called.args != nil && argsEqual(r.args, *called.args) {
+				continue responses
 			}
-			return fmt.Errorf("%w: %w", ErrExpectationsNotMet, errors.Join(mock.errs...))
 		}
+		errs = append(errs, fmt.Errorf("%w: %v", ErrResultNotUsed, r.args))
 	}
-	if len(mock.errs) > 0 {
-		return fmt.Errorf("%w: %w", ErrExpectationsNotMet, errors.Join(mock.errs...))
+
+	for _, ex := range mock.expectations {
+		if !ex.isSatisfied() {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrMissingCalls, ex.describe()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrExpectationsNotMet, errors.Join(errs...))
 	}
 	return nil
 }
 
 func (mock *MockFn[A, R]) ExpectCall() *mockFnCall[A, R] {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
 	if mock.responses != nil {
 		panic(fmt.Errorf("%w: cannot combine expected calls with mapped results", ErrInvalidOperation))
 	}
@@ -337,36 +878,141 @@ func (mock *MockFn[A, R]) ExpectCall() *mockFnCall[A, R] {
 	mock.expectations = append(mock.expectations, ex)
 	if mock.expected == nil {
 		mock.expected = ex
+		mock.idxExpected = len(mock.expectations) - 1
 	}
 	return ex
 }
 
+// InAnyOrder configures the mock function to accept expected calls in any order.
+//
+// By default expected calls must be made in the order in which they are configured.
+// When configured to accept calls in any order, an actual call is matched with any
+// expected call with matching arguments that has not yet been made the maximum number
+// of times expected.  A call that does not match any such expected call is unexpected.
+//
+// To test the order of calls across a number of mock functions, use InOrder.
+func (mock *MockFn[A, R]) InAnyOrder() {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	mock.unordered = true
+}
+
 // Reset sets the mock function to its zero value (no errors, no expected or recorded calls
 // and no mapped results).
 func (mock *MockFn[A, R]) Reset() {
-	*mock = MockFn[A, R]{}
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	// the mutex is held, so fields are reset individually rather than
+	// replacing the mock with a zero value
+	mock.actual = nil
+	mock.expectations = nil
+	mock.expected = nil
+	mock.idxExpected = 0
+	mock.responses = nil
+	mock.otherwise = nil
+	mock.lenient = false
+	mock.errs = nil
+	mock.unordered = false
+	mock.seq = nil
+	mock.seqIdx = 0
+	mock.captor = nil
 }
 
-// ResultFor returns the Fake[R] value configured for the specified arguments. This method
-// is called by a mock implementation to return the result and/or error configured for a
-// specific set of arguments.
+// Capture attaches a Captor to the mock function, capturing the arguments of all
+// calls to the function, including calls that do not meet expectations and calls
+// made to obtain mapped results using ResultFor().
 //
-// # errors
+// To capture only the arguments of calls matched with a particular expected call,
+// attach the Captor to the expected call instead.
 //
-// In the event of an error, the function will panic with one of the following errors:
+// If c is nil, Capture panics with ErrInvalidArgument; if a Captor is already
+// attached to the mock function, Capture panics with ErrInvalidOperation.
+func (mock *MockFn[A, R]) Capture(c *Captor[A]) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	switch {
+	case c == nil:
+		panic(fmt.Errorf("%w: a captor must be specified", ErrInvalidArgument))
+	case mock.captor != nil:
+		panic(fmt.Errorf("%w: captor already attached", ErrInvalidOperation))
+	}
+
+	mock.captor = c
+}
+
+// RecordedCall is a snapshot of a call recorded by a mock function.
+type RecordedCall[A any] struct {
+	// Args holds the arguments recorded for the call; the zero value of A if no
+	// arguments were recorded
+	Args A
+
+	// HasArgs is true if arguments were recorded for the call
+	HasArgs bool
+
+	// Err is any error determined when the call was recorded (e.g. ErrUnexpectedArgs);
+	// nil if the call met expectations
+	Err error
+}
+
+// RecordedCalls returns a snapshot of the calls recorded by the mock function, in the
+// order in which they were made.  The snapshot is consistent even if the mock function
+// is being called concurrently.
+func (mock *MockFn[A, R]) RecordedCalls() []RecordedCall[A] {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	result := make([]RecordedCall[A], len(mock.actual))
+	for i, call := range mock.actual {
+		result[i].Err = call.err
+		if call.args != nil {
+			result[i].Args = *call.args
+			result[i].HasArgs = true
+		}
+	}
+	return result
+}
+
+// ResultFor returns the result and error for a call to the mock function with specified
+// arguments.  This method is called by a mock implementation to record a call and return
+// the result and/or error configured for a specific set of arguments.
+//
+// ResultFor is equivalent to RecordCall (with arguments); calls recorded by either method
+// are recorded in the same way, whether the mock function is configured for expected calls
+// or mapped results.
+//
+// For a mock function configured for mapped results, the result and/or error is that
+// configured for the arguments using WhenCalledWith; if no result is configured for the
+// arguments, the result configured using WhenCalledWithAnything or Otherwise (if any).
+//
+// If the FakeResult is configured with a sequence of results (see FakeResult.ThenReturns),
+// each call returns the next result in the sequence.  A call made once a sequence configured
+// with FailWhenExhausted has been exhausted returns ErrResultsExhausted, which is also
+// reported by ExpectationsWereMet.
+//
+// # errors
 //
-//	ErrInvalidOperation     // when the mock function is configured for expected calls
+// In the event of an error, the function will panic with the following error:
 //
 //	ErrNoResultForArgs      // when no result is configured for the specified arguments
+//	                        // (or for any other arguments) and the mock function is not
+//	                        // lenient
 func (mock *MockFn[A, R]) ResultFor(args A) FakeResult[R] {
-	if mock.responses == nil {
-		panic(fmt.Errorf("%w: mock function is configured for expected calls; use <fn>.ExpectedResult()", ErrInvalidOperation))
-	}
+	result, err := mock.RecordCall(args)
+	return FakeResult[R]{Result: result, Err: err}
+}
 
-	if result, ok := mock.responses[args]; ok {
-		return *result
+// response returns the response configured for the specified arguments, or nil
+// if no response is configured for those arguments.
+func (mock *MockFn[A, R]) response(args A) *mockFnResponse[A, R] {
+	for _, r := range mock.responses {
+		if argsEqual(r.args, args) {
+			return r
+		}
 	}
-	panic(ErrNoResultForArgs)
+	return nil
 }
 
 // WhenCalledWith is used to configure the result for a specific set of arguments. This
@@ -388,6 +1034,10 @@ func (mock *MockFn[A, R]) ResultFor(args A) FakeResult[R] {
 // Returns a Fake[R] value that can be used to configure the result and/or error for the
 // specified arguments.
 //
+// A Captor cannot be attached to the result configured for specific arguments; to capture
+// the arguments of calls in mapped results mode, attach a Captor to the mock function
+// using Capture.
+//
 // # errors
 //
 // In the event of an error, the function will panic with one of the following errors:
@@ -397,21 +1047,97 @@ func (mock *MockFn[A, R]) ResultFor(args A) FakeResult[R] {
 //
 //	ErrInvalidArgument      // when a result is already configured for the specified arguments
 func (mock *MockFn[A, R]) WhenCalledWith(args A) *FakeResult[R] {
-	if len(mock.expectations) > 0 {
-		panic(fmt.Errorf("%w: cannot combine mapped results with expected calls", ErrInvalidOperation))
-	}
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 
-	switch mock.responses {
-	case nil:
-		mock.responses = make(map[A]*FakeResult[R])
-	default:
-		if _, ok := mock.responses[args]; ok {
-			panic(fmt.Errorf("%w: result already configured for args: %v", ErrInvalidArgument, args))
-		}
+	mock.ensureMappedResults()
+
+	if mock.response(args) != nil {
+		panic(fmt.Errorf("%w: result already configured for args: %v", ErrInvalidArgument, args))
 	}
 
 	r := &FakeResult[R]{}
-	mock.responses[args] = r
+	mock.responses = append(mock.responses, &mockFnResponse[A, R]{args: args, result: r})
 
 	return r
 }
+
+// WhenCalledWithAnything is used to configure the result for any arguments for which no
+// result is configured using WhenCalledWith, i.e. a default result for a mock function
+// configured for mapped results:
+//
+//	mock.WhenCalledWith("admin").Returns(adminUser)
+//	mock.WhenCalledWithAnything().Returns(nil, ErrNotFound)
+//
+// A default result is not required to be used; ExpectationsWereMet does not report an
+// unused default result.
+//
+// The arguments of calls obtaining the default result may be captured by attaching a
+// Captor to the mock function using Capture.
+//
+// # errors
+//
+// In the event of an error, the function will panic with the following error:
+//
+//	ErrInvalidOperation     // when the mock function already has one or more expected calls
+//	                        // configured, or a default result is already configured
+func (mock *MockFn[A, R]) WhenCalledWithAnything() *FakeResult[R] {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	mock.ensureMappedResults()
+
+	if mock.otherwise != nil {
+		panic(fmt.Errorf("%w: default result already configured", ErrInvalidOperation))
+	}
+
+	mock.otherwise = &FakeResult[R]{}
+	return mock.otherwise
+}
+
+// Otherwise configures the result and/or error to be returned for any arguments for
+// which no result is configured using WhenCalledWith.  The values are specified as for
+// FakeResult.Returns:
+//
+//	mock.WhenCalledWith("admin").Returns(adminUser)
+//	mock.Otherwise(nil, ErrNotFound)
+//
+// This is equivalent to:
+//
+//	mock.WhenCalledWithAnything().Returns(nil, ErrNotFound)
+func (mock *MockFn[A, R]) Otherwise(v ...any) {
+	mock.WhenCalledWithAnything().Returns(v...)
+}
+
+// Lenient configures a mock function for mapped results to return zero values for a
+// call with arguments for which no result is configured (and no default result is
+// configured), rather than panicking with ErrNoResultForArgs.
+//
+// Such calls are recorded as unexpected, with ErrUnexpectedCall, so that the mock
+// function does not meet expectations; the code under test however continues with
+// the zero values.
+//
+// # errors
+//
+// In the event of an error, the function will panic with the following error:
+//
+//	ErrInvalidOperation     // when the mock function already has one or more expected calls
+//	                        // configured
+func (mock *MockFn[A, R]) Lenient() {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	mock.ensureMappedResults()
+	mock.lenient = true
+}
+
+// ensureMappedResults configures the mock function for mapped results, panicking if
+// the mock function already has expected calls configured
+func (mock *MockFn[A, R]) ensureMappedResults() {
+	if len(mock.expectations) > 0 {
+		panic(fmt.Errorf("%w: cannot combine mapped results with expected calls", ErrInvalidOperation))
+	}
+	if mock.responses == nil {
+		mock.responses = []*mockFnResponse[A, R]{}
+	}
+}
@@ -1,8 +1,13 @@
 package test //nolint:testpackage // tests private functions and types
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/blugnu/test/opt"
 )
 
 func byref[T any](v T) *T {
@@ -21,12 +26,16 @@ func TestMockFnRecordCall(t *testing.T) {
 			exec: func() {
 				// ARRANGE
 				sut := MockFn[int, int]{
-					responses: map[int]*FakeResult[int]{},
+					responses: []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}},
 				}
-				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
 
 				// ACT
-				_, _ = sut.RecordCall(42)
+				result, err := sut.RecordCall(42)
+
+				// ASSERT
+				Expect(result).To(Equal(84))
+				Expect(err).IsNil()
+				Expect(sut.actual).To(DeepEqual([]*mockFnCall[int, int]{{args: byref(42)}}))
 			},
 		},
 		{scenario: "unexpected call",
@@ -119,6 +128,64 @@ func TestMockFnRecordCall(t *testing.T) {
 				Expect(err).Is(ErrExpectedArgs)
 			},
 		},
+		{scenario: "arguments matching",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[string, int]{}
+				sut.ExpectCall().WithArgsMatching(HavePrefix("user:")).WillReturn(1)
+
+				// ACT
+				result, err := sut.RecordCall("user:42")
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(result).To(Equal(1))
+			},
+		},
+		{scenario: "arguments not matching",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[string, int]{}
+				sut.ExpectCall().WithArgsMatching(HavePrefix("user:")).WillReturn(1)
+
+				// ACT
+				result, err := sut.RecordCall("group:42")
+
+				// ASSERT
+				Expect(result).To(Equal(1))
+				Expect(err).Is(ErrUnexpectedArgs)
+				Expect(err.Error()).To(ContainStringsInOrder(
+					"\n  expected: string beginning with: \"user:\"",
+					"\n  got     : \"group:42\"",
+				))
+			},
+		},
+		{scenario: "arguments matching expected but not recorded",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[string, int]{}
+				sut.ExpectCall().WithArgsMatching(AnyArgs[string]())
+
+				// ACT
+				_, err := sut.RecordCall()
+
+				// ASSERT
+				Expect(err).Is(ErrExpectedArgs)
+			},
+		},
+		{scenario: "any arguments",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[string, int]{}
+				sut.ExpectCall().WithArgsMatching(AnyArgs[string]())
+
+				// ACT
+				_, err := sut.RecordCall("anything")
+
+				// ASSERT
+				Expect(err).IsNil()
+			},
+		},
 		{scenario: "call expected regardless of arguments",
 			exec: func() {
 				// ARRANGE
@@ -145,6 +212,96 @@ func TestMockFnRecordCall(t *testing.T) {
 	}
 }
 
+func TestMockFnNonComparableArgs(t *testing.T) {
+	With(t)
+
+	type event struct {
+		ID   int
+		Tags []string
+	}
+
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func()
+	}{
+		{scenario: "expected call/equal slice",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[[]event, int]{}
+				sut.ExpectCall().WithArgs([]event{{ID: 1, Tags: []string{"a"}}}).WillReturn(1)
+
+				// ACT
+				result, err := sut.RecordCall([]event{{ID: 1, Tags: []string{"a"}}})
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(result).To(Equal(1))
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "expected call/different slice",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[[]event, int]{}
+				sut.ExpectCall().WithArgs([]event{{ID: 1, Tags: []string{"a"}}})
+
+				// ACT
+				_, err := sut.RecordCall([]event{{ID: 1, Tags: []string{"b"}}})
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedArgs)
+			},
+		},
+		{scenario: "expected call/any holding a non-comparable value",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[any, int]{}
+				sut.ExpectCall().WithArgs(map[string]int{"a": 1})
+				sut.ExpectCall().WithArgs(map[string]int{"a": 1})
+
+				// ACT
+				_, err1 := sut.RecordCall(map[string]int{"a": 1})
+				_, err2 := sut.RecordCall(1)
+
+				// ASSERT
+				Expect(err1).IsNil()
+				Expect(err2).Is(ErrUnexpectedArgs)
+			},
+		},
+		{scenario: "mapped results",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[event, int]{}
+				sut.WhenCalledWith(event{ID: 1, Tags: []string{"a"}}).Returns(1)
+				sut.WhenCalledWith(event{ID: 1, Tags: []string{"b"}}).Returns(2)
+
+				// ACT
+				result := sut.ResultFor(event{ID: 1, Tags: []string{"b"}})
+
+				// ASSERT
+				Expect(result.Result).To(Equal(2))
+			},
+		},
+		{scenario: "mapped results/duplicate arguments",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[event, int]{}
+				sut.WhenCalledWith(event{ID: 1, Tags: []string{"a"}})
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				sut.WhenCalledWith(event{ID: 1, Tags: []string{"a"}})
+			},
+		},
+	}
+	for _, tc := range testcases {
+		Run(Test(tc.scenario, func() {
+			tc.exec()
+		}))
+	}
+}
+
 func TestMockFnExpectationsWereMet(t *testing.T) {
 	With(t)
 
@@ -201,7 +358,7 @@ func TestMockFnExpectationsWereMet(t *testing.T) {
 			exec: func() {
 				// ARRANGE
 				sut := MockFn[int, int]{
-					responses: map[int]*FakeResult[int]{42: {Result: 84}},
+					responses: []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}},
 				}
 
 				// ACT
@@ -216,7 +373,7 @@ func TestMockFnExpectationsWereMet(t *testing.T) {
 			exec: func() {
 				// ARRANGE
 				sut := MockFn[int, int]{
-					responses: map[int]*FakeResult[int]{42: {Result: 84}},
+					responses: []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}},
 					actual:    []*mockFnCall[int, int]{{args: byref(42), result: 84}},
 				}
 
@@ -247,7 +404,7 @@ func TestMockFnExpectCall(t *testing.T) {
 			exec: func() {
 				// ARRANGE
 				sut := MockFn[int, int]{
-					responses: map[int]*FakeResult[int]{},
+					responses: []*mockFnResponse[int, int]{},
 				}
 				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
 
@@ -298,11 +455,13 @@ func TestMockFnReset(t *testing.T) {
 
 	// ARRANGE
 	sut := MockFn[int, int]{
-		responses:    map[int]*FakeResult[int]{42: {Result: 84}},
+		responses:    []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}},
 		expectations: []*mockFnCall[int, int]{{args: byref(42), result: 84}},
 		expected:     &mockFnCall[int, int]{args: byref(42), result: 84},
 		actual:       []*mockFnCall[int, int]{{args: byref(42), result: 84}},
 		idxExpected:  1,
+		otherwise:    &FakeResult[int]{Result: 21},
+		lenient:      true,
 		errs:         []error{errors.New("expected error")},
 	}
 
@@ -310,7 +469,7 @@ func TestMockFnReset(t *testing.T) {
 	sut.Reset()
 
 	// ASSERT
-	Expect(sut).To(DeepEqual(MockFn[int, int]{}))
+	Expect(&sut).To(DeepEqual(&MockFn[int, int]{}))
 }
 
 func TestMockFnResultFor(t *testing.T) {
@@ -324,20 +483,22 @@ func TestMockFnResultFor(t *testing.T) {
 		{scenario: "expected calls are configured",
 			exec: func() {
 				// ARRANGE
-				sut := MockFn[int, int]{
-					expectations: []*mockFnCall[int, int]{{args: byref(42), result: 84}},
-				}
-				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(42).WillReturn(84)
 
 				// ACT
-				sut.ResultFor(42)
+				result := sut.ResultFor(42)
+
+				// ASSERT
+				Expect(result).To(Equal(FakeResult[int]{Result: 84}))
+				Expect(sut.ExpectationsWereMet()).IsNil()
 			},
 		},
 		{scenario: "no result configured for arguments",
 			exec: func() {
 				// ARRANGE
 				sut := MockFn[int, int]{
-					responses: map[int]*FakeResult[int]{42: {Result: 84}},
+					responses: []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}},
 				}
 				defer Expect(Panic(ErrNoResultForArgs)).DidOccur()
 
@@ -349,7 +510,7 @@ func TestMockFnResultFor(t *testing.T) {
 			exec: func() {
 				// ARRANGE
 				sut := MockFn[int, int]{
-					responses: map[int]*FakeResult[int]{42: {Result: 84}},
+					responses: []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}},
 				}
 
 				// ACT
@@ -391,7 +552,7 @@ func TestMockFnWhenCalledWith(t *testing.T) {
 			exec: func() {
 				// ARRANGE + ASSERT
 				sut := MockFn[int, int]{}
-				sut.responses = map[int]*FakeResult[int]{42: {Result: 84}}
+				sut.responses = []*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}}
 
 				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
 
@@ -408,7 +569,7 @@ func TestMockFnWhenCalledWith(t *testing.T) {
 				sut.WhenCalledWith(42).Returns(84)
 
 				// ASSERT
-				Expect(sut.responses).To(EqualMap(map[int]*FakeResult[int]{42: {Result: 84}}))
+				Expect(sut.responses).To(DeepEqual([]*mockFnResponse[int, int]{{args: 42, result: &FakeResult[int]{Result: 84}}}))
 			},
 		},
 	}
@@ -548,6 +709,50 @@ func TestMockFnCallWithArgs(t *testing.T) {
 				_ = sut.WithArgs(42)
 			},
 		},
+		{scenario: "matcher configured",
+			exec: func() {
+				// ARRANGE
+				sut := &mockFnCall[int, int]{}
+				m := Equal(42)
+
+				// ACT
+				result := sut.WithArgsMatching(m)
+
+				// ASSERT
+				Expect(sut).To(DeepEqual(&mockFnCall[int, int]{matcher: m}))
+				Expect(result).To(Equal(sut))
+			},
+		},
+		{scenario: "args and matcher configured",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := &mockFnCall[int, int]{}
+				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+				// ACT
+				_ = sut.WithArgs(42).WithArgsMatching(Equal(42))
+			},
+		},
+		{scenario: "matcher and args configured",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := &mockFnCall[int, int]{}
+				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+				// ACT
+				_ = sut.WithArgsMatching(Equal(42)).WithArgs(42)
+			},
+		},
+		{scenario: "nil matcher",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := &mockFnCall[int, int]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				_ = sut.WithArgsMatching(nil)
+			},
+		},
 	}
 	for _, tc := range testcases {
 		Run(Test(tc.scenario, func() {
@@ -555,3 +760,772 @@ func TestMockFnCallWithArgs(t *testing.T) {
 		}))
 	}
 }
+
+func TestMockFnCallTimes(t *testing.T) {
+	With(t)
+
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func()
+	}{
+		{scenario: "times/met",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(42).Times(3).WillReturn(84)
+
+				// ACT
+				for i := 0; i < 3; i++ {
+					result, err := sut.RecordCall(42)
+					Expect(err).IsNil()
+					Expect(result).To(Equal(84))
+				}
+
+				// ASSERT
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "times/too few calls",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(42).Times(3)
+
+				// ACT
+				_, _ = sut.RecordCall(42)
+
+				// ASSERT
+				err := sut.ExpectationsWereMet()
+				Expect(err).Is(ErrExpectationsNotMet)
+				Expect(err).Is(ErrMissingCalls)
+				Expect(err.Error()).To(ContainString("expected 3 calls with args 42, got 1"))
+			},
+		},
+		{scenario: "times/too many calls",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(42).Times(2)
+
+				// ACT
+				_, _ = sut.RecordCall(42)
+				_, _ = sut.RecordCall(42)
+				_, err := sut.RecordCall(42)
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedCall)
+				Expect(err.Error()).To(ContainString("expected 2 calls with args 42, got 3"))
+			},
+		},
+		{scenario: "at least/met",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(1).AtLeast(2)
+				sut.ExpectCall().WithArgs(2)
+
+				// ACT
+				_, _ = sut.RecordCall(1)
+				_, _ = sut.RecordCall(1)
+				_, _ = sut.RecordCall(1)
+				_, _ = sut.RecordCall(2)
+
+				// ASSERT
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "at least/not met",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().AtLeast(2)
+
+				// ACT
+				_, _ = sut.RecordCall(1)
+
+				// ASSERT
+				err := sut.ExpectationsWereMet()
+				Expect(err).Is(ErrMissingCalls)
+				Expect(err.Error()).To(ContainString("expected at least 2 calls, got 1"))
+			},
+		},
+		{scenario: "at most/not called",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(1).AtMost(2)
+				sut.ExpectCall().WithArgs(2)
+
+				// ACT
+				_, err := sut.RecordCall(2)
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "at most/exceeded",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(1).AtMost(1)
+
+				// ACT
+				_, _ = sut.RecordCall(1)
+				_, err := sut.RecordCall(1)
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedCall)
+				Expect(err.Error()).To(ContainString("expected at most 1 call with args 1, got 2"))
+			},
+		},
+		{scenario: "any times",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(1).AnyTimes().WillReturn(10)
+				sut.ExpectCall().WithArgs(2).AnyTimes().WillReturn(20)
+
+				// ACT
+				r1, _ := sut.RecordCall(1)
+				r2, _ := sut.RecordCall(2)
+				r3, _ := sut.RecordCall(2)
+
+				// ASSERT
+				Expect(r1).To(Equal(10))
+				Expect(r2).To(Equal(20))
+				Expect(r3).To(Equal(20))
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "never/not called",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(0).Never()
+				sut.ExpectCall().WithArgs(1)
+
+				// ACT
+				_, err := sut.RecordCall(1)
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "never/called",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(1)
+				sut.ExpectCall().WithArgs(0).Never()
+
+				// ACT
+				_, err := sut.RecordCall(0)
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedCall)
+				Expect(err.Error()).To(ContainString("expected no calls with args 0, got 1"))
+			},
+		},
+		{scenario: "invalid number of calls",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				sut.ExpectCall().Times(-1)
+			},
+		},
+		{scenario: "number of calls already configured",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+				// ACT
+				sut.ExpectCall().Times(2).AtLeast(1)
+			},
+		},
+		{scenario: "expected call not made",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(42)
+
+				// ACT
+				err := sut.ExpectationsWereMet()
+
+				// ASSERT
+				Expect(err).Is(ErrMissingCalls)
+				Expect(err.Error()).To(ContainString("expected 1 call with args 42, got 0"))
+			},
+		},
+	}
+	for _, tc := range testcases {
+		Run(Test(tc.scenario, func() {
+			tc.exec()
+		}))
+	}
+}
+
+func TestMockFnInAnyOrder(t *testing.T) {
+	With(t)
+
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func()
+	}{
+		{scenario: "calls in different order",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.InAnyOrder()
+				sut.ExpectCall().WithArgs(1).WillReturn(10)
+				sut.ExpectCall().WithArgs(2).WillReturn(20)
+				sut.ExpectCall().WithArgs(3).WillReturn(30)
+
+				// ACT
+				r3, err3 := sut.RecordCall(3)
+				r1, err1 := sut.RecordCall(1)
+				r2, err2 := sut.RecordCall(2)
+
+				// ASSERT
+				Expect(err1).IsNil()
+				Expect(err2).IsNil()
+				Expect(err3).IsNil()
+				Expect([]int{r1, r2, r3}).To(EqualSlice([]int{10, 20, 30}))
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "unsatisfied expectation preferred",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.InAnyOrder()
+				sut.ExpectCall().AnyTimes().WillReturn(0)
+				sut.ExpectCall().WithArgs(1).WillReturn(10)
+
+				// ACT
+				r1, _ := sut.RecordCall(1)
+				r2, _ := sut.RecordCall(1)
+
+				// ASSERT
+				Expect(r1).To(Equal(10))
+				Expect(r2).To(Equal(0))
+				Expect(sut.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "no matching expectation",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.InAnyOrder()
+				sut.ExpectCall().WithArgs(1)
+
+				// ACT
+				_, err := sut.RecordCall(2)
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedCall)
+				Expect(sut.ExpectationsWereMet()).Is(ErrMissingCalls)
+			},
+		},
+		{scenario: "expectation exhausted",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.InAnyOrder()
+				sut.ExpectCall().WithArgs(1).Times(2)
+
+				// ACT
+				_, _ = sut.RecordCall(1)
+				_, _ = sut.RecordCall(1)
+				_, err := sut.RecordCall(1)
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedCall)
+				Expect(err.Error()).To(ContainString("expected 2 calls with args 1, got 3"))
+			},
+		},
+	}
+	for _, tc := range testcases {
+		Run(Test(tc.scenario, func() {
+			tc.exec()
+		}))
+	}
+}
+
+func TestMockFnRecordedCalls(t *testing.T) {
+	With(t)
+
+	// ARRANGE
+	sut := MockFn[int, int]{}
+	sut.ExpectCall().WithArgs(1)
+	sut.ExpectCall()
+
+	_, _ = sut.RecordCall(2)
+	_, _ = sut.RecordCall()
+
+	// ACT
+	result := sut.RecordedCalls()
+
+	// ASSERT
+	Expect(result).To(DeepEqual([]RecordedCall[int]{
+		{Args: 2, HasArgs: true, Err: ErrUnexpectedArgs},
+		{},
+	}))
+}
+
+func TestMockFnConcurrency(t *testing.T) {
+	With(t)
+
+	const n = 50
+
+	Run(Test("expected calls", func() {
+		// ARRANGE
+		sut := MockFn[int, int]{}
+		sut.InAnyOrder()
+		sut.ExpectCall().Times(n).WillReturn(42)
+
+		// ACT
+		wg := sync.WaitGroup{}
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, _ = sut.RecordCall(i)
+				_ = sut.RecordedCalls()
+			}(i)
+		}
+		wg.Wait()
+
+		// ASSERT
+		Expect(sut.RecordedCalls()).Should(HaveLen(n))
+		Expect(sut.ExpectationsWereMet()).IsNil()
+	}))
+
+	Run(Test("mapped results", func() {
+		// ARRANGE
+		sut := MockFn[int, int]{}
+		fake := sut.WhenCalledWith(1)
+		fake.Returns(42)
+
+		// ACT
+		wg := sync.WaitGroup{}
+		for i := 0; i < n; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = sut.ResultFor(1)
+			}()
+			go func() {
+				defer wg.Done()
+				fake.Returns(42)
+			}()
+		}
+		wg.Wait()
+
+		// ASSERT
+		Expect(sut.ResultFor(1).Result).To(Equal(42))
+	}))
+
+	Run(Test("sequence", func() {
+		// ARRANGE
+		a := MockFn[any, any]{}
+		b := MockFn[any, any]{}
+		InOrder(
+			a.ExpectCall().AnyTimes(),
+			b.ExpectCall().AnyTimes(),
+		)
+
+		// ACT
+		wg := sync.WaitGroup{}
+		for i := 0; i < n; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _ = a.RecordCall()
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = b.RecordCall()
+			}()
+		}
+		wg.Wait()
+
+		// ASSERT
+		Expect(a.ExpectationsWereMet()).IsNil()
+		Expect(b.ExpectationsWereMet()).IsNil()
+	}))
+}
+
+func TestMockFnCallBehaviours(t *testing.T) {
+	With(t)
+
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func()
+	}{
+		{scenario: "will call",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(21).WillCall(func(a int) (int, error) { return a * 2, nil })
+
+				// ACT
+				result, err := sut.RecordCall(21)
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(result).To(Equal(42))
+			},
+		},
+		{scenario: "will call/unexpected args",
+			exec: func() {
+				// ARRANGE
+				called := false
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WithArgs(21).WillCall(func(a int) (int, error) { called = true; return a * 2, nil })
+
+				// ACT
+				_, err := sut.RecordCall(1)
+
+				// ASSERT
+				Expect(err).Is(ErrUnexpectedArgs)
+				Expect(called).To(BeFalse())
+			},
+		},
+		{scenario: "will call/nil function",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				sut.ExpectCall().WillCall(nil)
+			},
+		},
+		{scenario: "will panic",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WillPanic("dependency crashed")
+				defer Expect(Panic("dependency crashed")).DidOccur()
+
+				// ACT
+				_, _ = sut.RecordCall()
+			},
+		},
+		{scenario: "will delay",
+			exec: func() {
+				// ARRANGE
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WillDelay(20 * time.Millisecond).WillReturn(42)
+
+				// ACT
+				start := time.Now()
+				result, err := sut.RecordCall()
+				elapsed := time.Since(start)
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(result).To(Equal(42))
+				Expect(elapsed >= 20*time.Millisecond).To(BeTrue())
+			},
+		},
+		{scenario: "will block until channel closed",
+			exec: func() {
+				// ARRANGE
+				unblock := make(chan struct{})
+				sut := MockFn[int, int]{}
+				sut.InAnyOrder()
+				sut.ExpectCall().WithArgs(1).WillBlockUntil(unblock).WillReturn(42)
+				sut.ExpectCall().WithArgs(2).WillReturn(84)
+
+				// ACT
+				done := make(chan int)
+				go func() {
+					result, _ := sut.RecordCall(1)
+					done <- result
+				}()
+
+				// ASSERT
+				select {
+				case <-done:
+					Expect(false).To(BeTrue(), opt.OnFailure("call returned before being unblocked"))
+				case <-time.After(20 * time.Millisecond):
+				}
+
+				// a blocked call does not prevent other calls
+				result, err := sut.RecordCall(2)
+				Expect(err).IsNil()
+				Expect(result).To(Equal(84))
+
+				close(unblock)
+				Expect(<-done).To(Equal(42))
+			},
+		},
+		{scenario: "will block until context done",
+			exec: func() {
+				// ARRANGE
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+				sut := MockFn[int, int]{}
+				sut.ExpectCall().WillBlockUntil(ctx)
+
+				// ACT
+				_, err := sut.RecordCall()
+
+				// ASSERT
+				Expect(err).IsNil()
+				Expect(ctx.Err()).Is(context.DeadlineExceeded)
+			},
+		},
+		{scenario: "will block until/invalid argument",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				sut.ExpectCall().WillBlockUntil(time.Second)
+			},
+		},
+		{scenario: "will block until/context never done",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				sut.ExpectCall().WillBlockUntil(context.Background())
+			},
+		},
+		{scenario: "will block until/nil channel",
+			exec: func() {
+				// ARRANGE + ASSERT
+				sut := MockFn[int, int]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				sut.ExpectCall().WillBlockUntil((chan struct{})(nil))
+			},
+		},
+		{scenario: "mapped result will block until channel closed",
+			exec: func() {
+				// ARRANGE
+				unblock := make(chan struct{})
+				sut := MockFn[int, int]{}
+				sut.WhenCalledWith(1).WillBlockUntil(unblock).Returns(42)
+				sut.WhenCalledWith(2).Returns(84)
+
+				// ACT
+				done := make(chan int)
+				go func() {
+					done <- sut.ResultFor(1).Result
+				}()
+
+				// ASSERT
+				Expect(sut.ResultFor(2).Result).To(Equal(84))
+				close(unblock)
+				Expect(<-done).To(Equal(42))
+			},
+		},
+	}
+	for _, tc := range testcases {
+		Run(Test(tc.scenario, func() {
+			tc.exec()
+		}))
+	}
+}
+
+func TestMockFnResultForSequence(t *testing.T) {
+	With(t)
+
+	errUnavailable := errors.New("unavailable")
+
+	Run(Test("error, error, success", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.WhenCalledWith("key").ThenReturns(errUnavailable).ThenReturns(42).Returns(errUnavailable)
+
+		// ACT
+		r1 := sut.ResultFor("key")
+		r2 := sut.ResultFor("key")
+		r3 := sut.ResultFor("key")
+
+		// ASSERT
+		Expect(r1.Err).Is(errUnavailable)
+		Expect(r2.Err).Is(errUnavailable)
+		Expect(r3).To(Equal(FakeResult[int]{Result: 42}))
+	}))
+
+	Run(Test("exhausted", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		fake := sut.WhenCalledWith("key")
+		fake.Returns(1)
+		fake.FailWhenExhausted()
+
+		// ACT
+		_ = sut.ResultFor("key")
+		result := sut.ResultFor("key")
+
+		// ASSERT
+		Expect(result.Err).Is(ErrResultsExhausted)
+		Expect(sut.ExpectationsWereMet()).Is(ErrResultsExhausted)
+	}))
+}
+
+func TestMockFnDefaultResults(t *testing.T) {
+	With(t)
+
+	Run(Test("WhenCalledWithAnything", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.WhenCalledWith("one").Returns(1)
+		sut.WhenCalledWithAnything().Returns(-1, ErrInvalidArgument)
+
+		// ACT
+		one := sut.ResultFor("one")
+		other, err := sut.RecordCall("other")
+		none, noneErr := sut.RecordCall()
+
+		// ASSERT
+		Expect(one).To(Equal(FakeResult[int]{Result: 1}))
+		Expect(other).To(Equal(-1))
+		Expect(err).Is(ErrInvalidArgument)
+		Expect(none).To(Equal(-1))
+		Expect(noneErr).Is(ErrInvalidArgument)
+		Expect(sut.RecordedCalls()).Should(HaveLen(3))
+		Expect(sut.ExpectationsWereMet()).IsNil()
+	}))
+
+	Run(Test("Otherwise", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.Otherwise(42)
+
+		// ACT
+		result, err := sut.RecordCall("any")
+
+		// ASSERT
+		Expect(result).To(Equal(42))
+		Expect(err).IsNil()
+		Expect(sut.ExpectationsWereMet()).IsNil()
+	}))
+
+	Run(Test("default result already configured", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.Otherwise(42)
+		defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+		// ACT
+		sut.WhenCalledWithAnything()
+	}))
+
+	Run(Test("default result with expected calls", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.ExpectCall()
+		defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+		// ACT
+		sut.Otherwise(42)
+	}))
+
+	Run(Test("expected call with default result", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.WhenCalledWithAnything()
+		defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+		// ACT
+		sut.ExpectCall()
+	}))
+
+	Run(Test("no result for args", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.WhenCalledWith("one").Returns(1)
+		defer Expect(Panic(ErrNoResultForArgs)).DidOccur()
+
+		// ACT
+		_, _ = sut.RecordCall("other")
+	}))
+
+	Run(Test("no result for args is not recorded", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.WhenCalledWith("one").Returns(1)
+
+		// ACT
+		func() {
+			defer Expect(Panic(ErrNoResultForArgs)).DidOccur()
+			_, _ = sut.RecordCall("other")
+		}()
+		_, _ = sut.RecordCall("one")
+
+		// ASSERT
+		Expect(sut.RecordedCalls()).To(DeepEqual([]RecordedCall[string]{
+			{Args: "one", HasArgs: true},
+		}))
+		Expect(sut.ExpectationsWereMet()).IsNil()
+	}))
+
+	Run(Test("lenient", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.Lenient()
+		sut.WhenCalledWith("one").Returns(1)
+
+		// ACT
+		one, _ := sut.RecordCall("one")
+		other, otherErr := sut.RecordCall("other")
+
+		// ASSERT
+		Expect(one).To(Equal(1))
+		Expect(other).To(Equal(0))
+		Expect(otherErr).IsNil()
+		Expect(sut.RecordedCalls()).To(DeepEqual([]RecordedCall[string]{
+			{Args: "one", HasArgs: true},
+			{Args: "other", HasArgs: true, Err: ErrUnexpectedCall},
+		}))
+
+		err := sut.ExpectationsWereMet()
+		Expect(err).Is(ErrUnexpectedCall)
+		Expect(err.Error()).To(ContainString("unexpected call: with args: other"))
+	}))
+
+	Run(Test("lenient with expected calls", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.ExpectCall()
+		defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+		// ACT
+		sut.Lenient()
+	}))
+
+	Run(Test("unused results", func() {
+		// ARRANGE
+		sut := MockFn[string, int]{}
+		sut.WhenCalledWith("one").Returns(1)
+		sut.WhenCalledWith("two").Returns(2)
+
+		// ACT
+		_, _ = sut.RecordCall("one")
+
+		// ASSERT
+		err := sut.ExpectationsWereMet()
+		Expect(err).Is(ErrResultNotUsed)
+		Expect(err.Error()).To(ContainString("result not used: two"))
+		Expect(err.Error()).ToNot(ContainString("result not used: one"))
+	}))
+}
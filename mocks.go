@@ -0,0 +1,153 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/blugnu/test/internal/testframe"
+	"github.com/blugnu/test/opt"
+)
+
+// registeredMocks holds the mocks registered for automatic verification in a
+// test frame.
+type registeredMocks struct {
+	names []string
+	mocks []Mock
+}
+
+// mockRegistry holds the mocks registered for automatic verification, by test
+// frame.  Mocks may be registered from parallel tests, so access is guarded.
+var mockRegistry = struct {
+	sync.Mutex
+	frames map[TestingT]*registeredMocks
+}{
+	frames: map[TestingT]*registeredMocks{},
+}
+
+// namedMock is a Mock with a name to identify it in a test failure report.
+type namedMock struct {
+	Mock
+	name string
+}
+
+// NamedMock returns a Mock with a name to identify it in the test failure
+// report produced if the expectations of the mock are not met when verified
+// by Mocks().
+func NamedMock(name string, m Mock) Mock {
+	return namedMock{Mock: m, name: name}
+}
+
+// Mocks registers mocks to be verified automatically when the current test
+// completes, ensuring that a test cannot forget to verify the expectations of
+// any mock it uses:
+//
+//	func TestSomething(t *testing.T) {
+//		With(t)
+//
+//		repo := &mockRepository{}
+//		bus := &mockEventBus{}
+//		Mocks(repo, NamedMock("event bus", bus))
+//
+//		// .. configure expectations on mocks
+//
+//		// ACT
+//		// .. call the code under test
+//
+//		// no need to call ExpectationsWereMet(); the expectations of
+//		// all registered mocks are verified when the test completes
+//	}
+//
+// Mocks may be called more than once in a test; all mocks registered in the
+// test are verified, in the order in which they were registered.  If the
+// expectations of any mocks are not met the test fails with a single report
+// identifying each of those mocks.  A mock is identified by its type unless a
+// name is provided using NamedMock().
+//
+// As with ExpectationsWereMet(), each mock is reset after it is verified.
+func Mocks(mocks ...Mock) {
+	t := T()
+	t.Helper()
+
+	mockRegistry.Lock()
+	defer mockRegistry.Unlock()
+
+	reg, ok := mockRegistry.frames[t]
+	if !ok {
+		reg = &registeredMocks{}
+		mockRegistry.frames[t] = reg
+		t.Cleanup(func() {
+			t.Helper()
+			verifyMocks(t)
+		})
+	}
+
+	for _, m := range mocks {
+		if m == nil {
+			panic(fmt.Errorf("Mocks: %w: nil mock", ErrInvalidArgument))
+		}
+
+		name := fmt.Sprintf("%T", m)
+		if nm, ok := m.(namedMock); ok {
+			name = nm.name
+		}
+
+		// mocks of the same type (or with the same name) are distinguished
+		// by the order in which they were registered
+		n := 1
+		for _, other := range reg.names {
+			if other == name || strings.HasPrefix(other, name+" (#") {
+				n++
+			}
+		}
+		if n > 1 {
+			name = fmt.Sprintf("%s (#%d)", name, n)
+		}
+
+		reg.names = append(reg.names, name)
+		reg.mocks = append(reg.mocks, m)
+	}
+}
+
+// verifyMocks verifies the expectations of the mocks registered for a test
+// frame, failing the test with a combined report if any were not met.
+//
+// This is called as a cleanup function of the test, by which time the frame
+// may no longer be the current frame, so it is pushed for the duration.
+func verifyMocks(t TestingT) {
+	mockRegistry.Lock()
+	reg := mockRegistry.frames[t]
+	delete(mockRegistry.frames, t)
+	mockRegistry.Unlock()
+
+	testframe.Push(t)
+	defer testframe.Pop()
+
+	t.Helper()
+
+	report := []string{}
+	failed := 0
+	for i, m := range reg.mocks {
+		err := m.ExpectationsWereMet()
+		m.Reset()
+		if err == nil {
+			continue
+		}
+
+		failed++
+		report = append(report, reg.names[i]+":")
+		for _, s := range strings.Split(err.Error(), "\n") {
+			report = append(report, "  "+s)
+		}
+	}
+
+	if failed == 0 {
+		return
+	}
+
+	report = append([]string{
+		fmt.Sprintf("expectations not met for %d of %d mocks:", failed, len(reg.mocks)),
+	}, report...)
+
+	Expect(failed).To(Equal(0), opt.FailureReport(func(...any) []string { return report }))
+}
@@ -0,0 +1,106 @@
+package test_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/blugnu/test"
+)
+
+func TestMocks(t *testing.T) {
+	With(t)
+
+	Run(Test("expectations met", func() {
+		// ARRANGE
+		m := &mock{}
+
+		// ACT
+		result := TestHelper(func() { Mocks(m) })
+
+		// ASSERT
+		result.Expect(TestPassed)
+		Expect(m.expectationsWereMet_wasCalled, "expectationsWereMet was called").Is(true)
+		Expect(m.reset_wasCalled, "reset was called").Is(true)
+	}))
+
+	Run(Test("expectations not met", func() {
+		// ARRANGE
+		m1 := &mock{err: errors.New("mock 1 error")}
+		m2 := &mock{}
+		m3 := &mock{err: errors.New("mock 3 error\non multiple lines")}
+
+		// ACT
+		result := TestHelper(func() {
+			Mocks(m1, m2)
+			Mocks(NamedMock("third mock", m3))
+		})
+
+		// ASSERT
+		result.Expect(
+			"expectations not met for 2 of 3 mocks:",
+			"*test_test.mock:",
+			"  mock 1 error",
+			"third mock:",
+			"  mock 3 error",
+			"  on multiple lines",
+		)
+	}))
+
+	Run(Test("mocks of the same type", func() {
+		// ARRANGE
+		m1 := &mock{}
+		m2 := &mock{err: errors.New("mock 2 error")}
+
+		// ACT
+		result := TestHelper(func() { Mocks(m1, m2) })
+
+		// ASSERT
+		result.Expect(
+			"expectations not met for 1 of 2 mocks:",
+			"*test_test.mock (#2):",
+			"  mock 2 error",
+		)
+	}))
+
+	Run(Test("mock function", func() {
+		// ARRANGE
+		fn := &MockFn[int, int]{}
+
+		// ACT
+		result := TestHelper(func() {
+			Mocks(NamedMock("fn", fn))
+			fn.ExpectCall().WithArgs(42)
+		})
+
+		// ASSERT
+		result.Expect(
+			"expectations not met for 1 of 1 mocks:",
+			"fn:",
+			"expected 1 call with args 42, got 0",
+		)
+	}))
+
+	Run(Test("registered in a subtest", func() {
+		// ARRANGE
+		m := &mock{err: errors.New("mock error")}
+
+		// ACT
+		result := TestHelper(func() {
+			Run(Test("subtest", func() {
+				Mocks(m)
+			}))
+		})
+
+		// ASSERT
+		result.Expect(
+			"expectations not met for 1 of 1 mocks:",
+			"*test_test.mock:",
+			"  mock error",
+		)
+	}))
+
+	Run(Test("nil mock", func() {
+		defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+		Mocks(nil)
+	}))
+}
@@ -17,6 +17,11 @@ type CaseSensitive bool
 // collection is significant (or not).
 type ExactOrder bool
 
+// HexDump may be used to indicate that byte slices should be presented in a
+// hexdump format (offsets, hex columns and an ASCII gutter) in test failure
+// reports, where supported.
+type HexDump bool
+
 // IgnoreReport may be used to indicate that the contents of any test report are not
 // significant when testing the result of testing a test, i.e. R.Expect().
 //
@@ -31,6 +36,15 @@ type IgnoreReport bool
 // see also: Require()
 type IsRequired bool
 
+// MaxDiffRegions may be used to limit the number of regions of differences
+// presented in a test failure report, where supported.  A value of zero (or
+// less) removes the limit.
+type MaxDiffRegions int
+
+// MaxParallel may be used to limit the number of parallel tests that run at
+// once, where supported.  A value of zero (or less) removes the limit.
+type MaxParallel int
+
 // NoPanic is an internal option used as a sentinel recover value by the panic
 // testing mechanism to signal that a panic is NOT expected to occur
 type NoPanicExpected bool
@@ -2,9 +2,11 @@ package test
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/blugnu/test/internal/testframe"
+	"github.com/blugnu/test/opt"
 	"github.com/blugnu/test/test"
 )
 
@@ -28,6 +30,84 @@ func isParallel(t TestingT) bool {
 	return ip.Bool()
 }
 
+// limiterKey identifies a semaphore limiting the number of parallel tests that
+// run at once: the parent of the tests (or nil for tests limited by Parallel)
+// and the limit.
+type limiterKey struct {
+	parent TestingT
+	n      int
+}
+
+// limiter is a semaphore limiting the number of parallel tests that run at
+// once, with the number of tests for which the semaphore is held
+type limiter struct {
+	sem  chan struct{}
+	refs int
+}
+
+// limiters holds the semaphores limiting the number of parallel tests that
+// run at once
+var limiters = struct {
+	sync.Mutex
+	m map[limiterKey]*limiter
+}{
+	m: map[limiterKey]*limiter{},
+}
+
+// parallelLimiter returns a semaphore limiting to n the number of parallel
+// tests that run at once, shared by all tests of the same parent (or nil) that
+// specify the same limit.
+//
+// The semaphore is held for an owner, the test that is cleaned up only once
+// the tests limited by the semaphore have completed: the parent (for subtests)
+// or the limited test itself (for tests limited by Parallel).  A semaphore is
+// removed when all of its owners have been cleaned up, so that any subsequent
+// tests establish a new semaphore.
+func parallelLimiter(parent TestingT, owner TestingT, n int) chan struct{} {
+	limiters.Lock()
+	defer limiters.Unlock()
+
+	key := limiterKey{parent: parent, n: n}
+	l, ok := limiters.m[key]
+	if !ok {
+		l = &limiter{sem: make(chan struct{}, n)}
+		limiters.m[key] = l
+	}
+	l.refs++
+
+	owner.Cleanup(func() {
+		limiters.Lock()
+		defer limiters.Unlock()
+
+		if l.refs--; l.refs == 0 {
+			delete(limiters.m, key)
+		}
+	})
+	return l.sem
+}
+
+// limitParallel blocks a parallel test until it may run within the limit of a
+// semaphore, releasing the semaphore when the test (and any subtests) have
+// completed.  A nil semaphore imposes no limit.
+//
+// This must be called after t.Parallel(); the semaphore cannot be acquired
+// before then since parallel tests are not resumed until their parent (or,
+// for top-level tests, all sequential tests) have completed: a test waiting
+// for the semaphore before calling t.Parallel() would block its parent and
+// never be released by the paused tests holding the semaphore.
+//
+// As a result, a test waiting for the semaphore occupies a slot of the
+// -parallel flag of go test; a limit is only useful if it is lower than the
+// value of that flag.
+func limitParallel(t TestingT, sem chan struct{}) {
+	if sem == nil {
+		return
+	}
+
+	sem <- struct{}{}
+	t.Cleanup(func() { <-sem })
+}
+
 // IsParallel returns true if the current test is running in parallel or is a
 // sub-test of a parallel test.
 func IsParallel() bool {
@@ -55,9 +135,25 @@ func IsParallel() bool {
 //	  }))
 //	}
 //
+// The number of tests using Parallel that run at once may be limited using
+// the opt.MaxParallel option, independently of the -parallel flag of go test:
+//
+//	func TestSomething(t *testing.T) {
+//	  Parallel(t, opt.MaxParallel(4))
+//	  // ... test code here ...
+//	}
+//
+// All tests specifying the same limit share that limit; tests specifying
+// different limits are limited independently.
+//
 // Parallel must not be called from a test that is already parallel or with
 // a nil argument; in both cases the test will be failed as invalid.
-func Parallel(t TestingT) {
+//
+// # Supported Options
+//
+//	opt.MaxParallel(n)    // limits the number of tests using Parallel (with
+//	                      // this option) that run at once
+func Parallel(t TestingT, opts ...any) {
 	if t == nil {
 		if t, ok := testframe.Peek[TestingT](); ok {
 			t.Helper()
@@ -73,4 +169,8 @@ func Parallel(t TestingT) {
 
 	With(t)
 	t.Parallel()
+
+	if n, ok := opt.Get[opt.MaxParallel](opts); ok && n > 0 {
+		limitParallel(t, parallelLimiter(nil, t, int(n)))
+	}
 }
@@ -1,11 +1,14 @@
 package test_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	. "github.com/blugnu/test"
 	"github.com/blugnu/test/internal/testframe"
+	"github.com/blugnu/test/opt"
 	"github.com/blugnu/test/test"
 )
 
@@ -181,3 +184,101 @@ func TestParallelTests(t *testing.T) {
 		}))
 	}))
 }
+
+func TestMaxParallel(t *testing.T) {
+	With(t)
+
+	// concurrency records the number of tests running at once and the
+	// maximum number observed.  The maximum observed cannot exceed the
+	// -parallel flag of go test (by default, GOMAXPROCS) so is tested only
+	// to be within the limit imposed
+	type concurrency struct {
+		sync.Mutex
+		running, max int
+	}
+
+	track := func(c *concurrency) {
+		c.Lock()
+		c.running++
+		c.max = max(c.max, c.running)
+		c.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		c.Lock()
+		c.running--
+		c.Unlock()
+	}
+
+	Run(Test("ParallelCases()", func() {
+		c := &concurrency{}
+
+		Run(Test("cases", func() {
+			Run(ParallelCases(
+				ForEach(func(int) { track(c) }),
+				MaxParallel[int](2),
+				Cases([]int{1, 2, 3, 4, 5}),
+			))
+		}))
+
+		Expect(c.max).To(BeBetween(1).And(2))
+	}))
+
+	Run(Test("ParallelTest()", func() {
+		c := &concurrency{}
+
+		Run(Test("tests", func() {
+			for i := 1; i <= 5; i++ {
+				Run(ParallelTest(fmt.Sprintf("test-%d", i), func() { track(c) }, opt.MaxParallel(2)))
+			}
+		}))
+
+		Expect(c.max).To(BeBetween(1).And(2))
+	}))
+
+	Run(Test("Parallel()", func() {
+		c := &concurrency{}
+
+		Run(Test("tests", func() {
+			for i := 1; i <= 5; i++ {
+				Run(Test(fmt.Sprintf("test-%d", i), func() {
+					Parallel(T(), opt.MaxParallel(2))
+					track(c)
+				}))
+			}
+		}))
+
+		Expect(c.max).To(BeBetween(1).And(2))
+	}))
+
+	Run(Test("Parallel() with different limits", func() {
+		// a limit established by earlier tests does not apply to subsequent
+		// tests specifying a different limit
+		two, one := &concurrency{}, &concurrency{}
+
+		for _, limit := range []struct {
+			n int
+			c *concurrency
+		}{{2, two}, {1, one}} {
+			Run(Test(fmt.Sprintf("limit %d", limit.n), func() {
+				for i := 1; i <= 5; i++ {
+					Run(Test(fmt.Sprintf("test-%d", i), func() {
+						Parallel(T(), opt.MaxParallel(limit.n))
+						track(limit.c)
+					}))
+				}
+			}))
+		}
+
+		Expect(two.max).To(BeBetween(1).And(2))
+		Expect(one.max).To(Equal(1))
+	}))
+
+	Run(Test("MaxParallel() with negative limit", func() {
+		result := TestHelper(func() {
+			MaxParallel[int](-1)
+		})
+
+		result.ExpectInvalid("MaxParallel() limit cannot be negative")
+	}))
+}
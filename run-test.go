@@ -3,6 +3,7 @@ package test
 import (
 	"testing"
 
+	"github.com/blugnu/test/opt"
 	"github.com/blugnu/test/test"
 )
 
@@ -13,6 +14,10 @@ type testRunner struct {
 	name     string
 	fn       func()
 	parallel bool
+
+	// maxParallel limits the number of parallel subtests of the same parent
+	// test that run at once; if zero, there is no limit
+	maxParallel int
 }
 
 // Run runs the named test function as a subtest in the current test frame
@@ -24,12 +29,18 @@ func (tr testRunner) Run() {
 		test.Invalid("ParallelTest() cannot be run from a parallel test")
 	}
 
+	var sem chan struct{}
+	if tr.parallel && tr.maxParallel > 0 {
+		sem = parallelLimiter(t, t, tr.maxParallel)
+	}
+
 	t.Run(tr.name, func(t *testing.T) {
 		With(t)
 		t.Helper()
 
 		if tr.parallel {
 			t.Parallel()
+			limitParallel(t, sem)
 		}
 
 		tr.fn()
@@ -39,20 +50,38 @@ func (tr testRunner) Run() {
 // ParallelTest creates a test runner to run a function as a subtest
 // with the provided name, running it in parallel.
 //
+// The number of parallel subtests of the current test that run at once may
+// be limited using the opt.MaxParallel option, independently of the -parallel
+// flag of go test:
+//
+//	Run(ParallelTest("first", func() { ... }, opt.MaxParallel(4)))
+//	Run(ParallelTest("second", func() { ... }, opt.MaxParallel(4)))
+//
+// All parallel subtests of the current test specifying the same limit share
+// that limit; subtests specifying different limits are limited independently.
+//
 // If the current test is already parallel, this function will
 // fail the test as invalid since it is not allowed to nest parallel
 // tests.
-func ParallelTest(name string, fn func()) testRunner {
+//
+// # Supported Options
+//
+//	opt.MaxParallel(n)    // limits the number of parallel subtests of the
+//	                      // current test (with this option) that run at once
+func ParallelTest(name string, fn func(), opts ...any) testRunner {
 	T().Helper()
 
 	if IsParallel() {
 		test.Invalid("ParallelTest() cannot be run from a parallel test")
 	}
 
+	n, _ := opt.Get[opt.MaxParallel](opts)
+
 	return testRunner{
-		name:     name,
-		fn:       fn,
-		parallel: true,
+		name:        name,
+		fn:          fn,
+		parallel:    true,
+		maxParallel: int(n),
 	}
 }
 
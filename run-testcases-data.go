@@ -0,0 +1,131 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/blugnu/test/internal/testcase"
+	"github.com/blugnu/test/test"
+)
+
+// CasesFromJSON adds test cases loaded from a JSON file.  The file must hold
+// either an array of objects, each of which is a test case, or an object with
+// a member for each test case, keyed by the name of the test case:
+//
+//	[
+//	  { "name": "empty input", "input": "", "result": 0 },
+//	  { "name": "single word", "input": "hello", "result": 1 }
+//	]
+//
+//	{
+//	  "empty input": { "input": "", "result": 0 },
+//	  "single word": { "input": "hello", "result": 1 }
+//	}
+//
+// Each member of a test case object sets the field of the test case with the
+// same name (or a name equal to the member name, ignoring case); unexported
+// fields are supported.  Values are decoded from the JSON according to the
+// type of the field.  A test case in an array is named by any "name" member.
+//
+// The type of test case must be a struct and must be specified:
+//
+//	Run(Testcases(
+//		ForEach(func(tc TestCase) { ... }),
+//		CasesFromJSON[TestCase]("testdata/cases.json"),
+//	))
+//
+// If the file cannot be loaded, or a test case has a value with no
+// corresponding field or which cannot be decoded as the type of the
+// corresponding field, the test fails as invalid.
+func CasesFromJSON[T any](path string) testcase.Registration[T] {
+	GetT().Helper()
+	return casesFrom[T]("CasesFromJSON", path, testcase.LoadJSON)
+}
+
+// CasesFromCSV adds test cases loaded from a CSV file.  The first row of the
+// file is a header naming the field of the test case set by each column; each
+// subsequent row is a test case.  Lines beginning with '#' are ignored:
+//
+//	# word counts
+//	name,input,result
+//	empty input,,0
+//	single word,hello,1
+//
+// Fields are matched to columns as for CasesFromJSON; values are parsed from
+// text according to the type of the field.  Types implementing
+// encoding.TextUnmarshaler are supported, time.Duration values are parsed
+// using time.ParseDuration, and slices, maps and structs are decoded as JSON.
+// An empty value sets any field other than a string to its zero value.  A test
+// case is named by any "name" column.
+//
+// If the file cannot be loaded, or a test case has a value with no
+// corresponding field or which cannot be parsed as the type of the
+// corresponding field, the test fails as invalid.
+func CasesFromCSV[T any](path string) testcase.Registration[T] {
+	GetT().Helper()
+	return casesFrom[T]("CasesFromCSV", path, testcase.LoadCSV)
+}
+
+// CasesFromDir adds test cases loaded from a directory in which each file
+// holds a single test case, named for the file (without any extension).
+//
+// Each file is a txtar-style archive, with a section for each field of the
+// test case introduced by a marker line naming the field.  Any text before
+// the first marker line is a comment:
+//
+//	checks that words are counted across lines
+//	-- input --
+//	hello
+//	world
+//	-- result --
+//	2
+//
+// The final newline of each section is removed and values are parsed from
+// text as for CasesFromCSV.  This is convenient for test cases with large or
+// multi-line values, such as the expected output of a test.
+//
+// Files are loaded in name order; subdirectories and files with names
+// beginning with '.' are ignored.
+//
+// If the directory cannot be loaded, or a test case has a value with no
+// corresponding field or which cannot be parsed as the type of the
+// corresponding field, the test fails as invalid.
+func CasesFromDir[T any](path string) testcase.Registration[T] {
+	GetT().Helper()
+	return casesFrom[T]("CasesFromDir", path, testcase.LoadDir)
+}
+
+// casesFrom returns a registration adding test cases loaded from a path
+// using a specified loader function
+func casesFrom[T any](
+	fn string,
+	path string,
+	load func(string) ([]testcase.Record, error),
+) testcase.Registration[T] {
+	GetT().Helper()
+
+	type namedCase struct {
+		name string
+		tc   T
+	}
+
+	recs, err := load(path)
+	if err != nil {
+		test.Invalid(fmt.Sprintf("%s(): %s", fn, err))
+	}
+
+	var base T
+	cases := make([]namedCase, len(recs))
+	for i, rec := range recs {
+		tc, err := rec.Apply(base)
+		if err != nil {
+			test.Invalid(fmt.Sprintf("%s(): %s: test case %d: %s", fn, path, i+1, err))
+		}
+		cases[i] = namedCase{name: rec.Name, tc: tc.(T)}
+	}
+
+	return func(r *testcase.Runner[T], flags testcase.Flags) {
+		for _, c := range cases {
+			r.AddCase(c.name, c.tc, flags)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/blugnu/test/internal/testcase"
+	"github.com/blugnu/test/test"
+)
+
+// Dim returns a named dimension of values for use with Matrix or Pairwise.
+// The name identifies the field of the test case to be set to each value
+// (by exact name or, if no field has that name, ignoring case) and is also
+// used in the names of the test cases generated.
+func Dim[V any](name string, values ...V) testcase.Dimension {
+	d := testcase.Dimension{Name: name, Values: make([]any, len(values))}
+	for i, v := range values {
+		d.Values[i] = v
+	}
+	return d
+}
+
+// Matrix adds test cases for every combination of the values of a number of
+// dimensions (the cross product of the dimensions).  Each test case is a copy
+// of a base test case, which must be a struct, with the field corresponding to
+// each dimension set to a value of that dimension:
+//
+//	type testcase struct {
+//		region string
+//		tier   string
+//		result int
+//	}
+//	Run(Testcases(
+//		ForEach(func(tc testcase) { ... }),
+//		Matrix(testcase{result: 42},
+//			Dim("region", "eu", "us"),
+//			Dim("tier", "gold", "silver", "bronze"),
+//		),
+//	))
+//
+// Test cases are named for the values of each dimension, e.g:
+//
+//	region=eu/tier=gold
+//
+// If the base test case is not a struct, has no field corresponding to a
+// dimension, or a value cannot be assigned to the corresponding field, the
+// test fails as invalid.
+func Matrix[T any](base T, dims ...testcase.Dimension) testcase.Registration[T] {
+	GetT().Helper()
+	return combinations("Matrix", base, dims, testcase.AllCombinations)
+}
+
+// Pairwise adds test cases for combinations of the values of a number of
+// dimensions such that every pair of values of any two dimensions is tested
+// (all-pairs testing).  This is typically far fewer test cases than the
+// cross product of the dimensions produced by Matrix.
+//
+// Test cases are otherwise generated and named as for Matrix.
+func Pairwise[T any](base T, dims ...testcase.Dimension) testcase.Registration[T] {
+	GetT().Helper()
+	return combinations("Pairwise", base, dims, testcase.PairwiseCombinations)
+}
+
+// combinations returns a registration adding test cases for the combinations
+// of dimensions generated by a specified function
+func combinations[T any](
+	fn string,
+	base T,
+	dims []testcase.Dimension,
+	generate func([]testcase.Dimension) []testcase.Combination,
+) testcase.Registration[T] {
+	GetT().Helper()
+
+	if err := testcase.ValidateDimensions(base, dims); err != nil {
+		test.Invalid(fmt.Sprintf("%s(): %s", fn, err))
+	}
+
+	type namedCase struct {
+		name string
+		tc   T
+	}
+
+	combos := generate(dims)
+	cases := make([]namedCase, len(combos))
+	for i, c := range combos {
+		tc, err := c.Apply(base, dims)
+		if err != nil {
+			test.Invalid(fmt.Sprintf("%s(): %s", fn, err))
+		}
+		cases[i] = namedCase{name: c.Name(dims), tc: tc.(T)}
+	}
+
+	return func(r *testcase.Runner[T], flags testcase.Flags) {
+		for _, c := range cases {
+			r.AddCase(c.name, c.tc, flags)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/blugnu/test/internal/testcase"
 	"github.com/blugnu/test/test"
 )
@@ -58,7 +61,67 @@ func Cases[T any](cases []T) testcase.Registration[T] {
 // test suite.
 func Debug[T any](name string, tc T) testcase.Registration[T] {
 	return func(r *testcase.Runner[T], flags testcase.Flags) {
-		r.AddCase(name, tc, flags|testcase.Debug)
+		r.AddCase(name, tc, flags&^testcase.Skip|testcase.Debug)
+	}
+}
+
+// Group adds a named group of test cases to the runner.  The test cases in a
+// group are run as subtests of a subtest named for the group; groups may be
+// nested to organise large numbers of test cases hierarchically:
+//
+//	Run(Testcases(
+//		ForEach(func(tc testcase) { ... }),
+//		Group("valid",
+//			Case("ascii", testcase{ ... }),
+//			Group("unicode",
+//				Case("accents", testcase{ ... }),
+//			),
+//		),
+//		Group("invalid",
+//			Case("empty", testcase{ ... }),
+//		),
+//	))
+//
+// This runs subtests such as "valid/unicode/accents", which may be selected
+// using the -run flag of go test in the usual way.
+//
+// Any flags applying to the group (e.g. if added to a runner using
+// ParallelCases(), or using DebugGroup() or SkipGroup()) apply to all test
+// cases in the group, including those in any nested groups.
+//
+// A summary of any test cases in the group that were skipped (or not run
+// when debugging) is logged in the subtest of the group; warnings for all
+// test cases are reported once, by the runner.
+func Group[T any](name string, cases ...testcase.Registration[T]) testcase.Registration[T] {
+	return func(r *testcase.Runner[T], flags testcase.Flags) {
+		r.Group(name, func() {
+			for _, reg := range cases {
+				reg(r, flags)
+			}
+		})
+	}
+}
+
+// DebugGroup adds a named group of test cases to the runner and marks all of
+// the test cases in the group as debug targets.
+//
+// Aside from marking the test cases as debug targets, this function is
+// otherwise identical to Group().
+func DebugGroup[T any](name string, cases ...testcase.Registration[T]) testcase.Registration[T] {
+	return func(r *testcase.Runner[T], flags testcase.Flags) {
+		Group(name, cases...)(r, flags&^testcase.Skip|testcase.Debug)
+	}
+}
+
+// SkipGroup adds a named group of test cases to the runner and marks all of
+// the test cases in the group to be skipped.  A test case in the group added
+// using Debug() is not skipped.
+//
+// Aside from marking the test cases to be skipped, this function is otherwise
+// identical to Group().
+func SkipGroup[T any](name string, cases ...testcase.Registration[T]) testcase.Registration[T] {
+	return func(r *testcase.Runner[T], flags testcase.Flags) {
+		Group(name, cases...)(r, flags|testcase.Skip)
 	}
 }
 
@@ -82,6 +145,169 @@ func Skip[T any](name string, tc T) testcase.Registration[T] {
 	}
 }
 
+// Timeout sets the time allowed for each test case to complete.  A test case
+// that does not complete in the time allowed fails, reporting the stack of the
+// goroutine running the test case to identify where it is stuck; the remaining
+// test cases are then run.
+//
+// A test case with a time.Duration timeout/Timeout field with a non-zero value
+// is instead allowed the time specified by that field; the field may be used to
+// set a timeout for individual test cases without setting one for the runner.
+//
+// The type of the test cases cannot be inferred, so must be specified:
+//
+//	Run(Testcases(
+//		ForEach(func(tc testcase) { ... }),
+//		Timeout[testcase](time.Second),
+//		Case("first", testcase{ ... }),
+//		Case("second", testcase{ ..., timeout: 5 * time.Second }),
+//	))
+//
+// A test case with a timeout is run in a separate goroutine, which cannot be
+// stopped if the test case does not complete; the goroutine is abandoned and
+// AfterEach functions are not called for that test case.  A test case that
+// completes after the test has timed out must not then report any failures.
+// When the number of parallel test cases is limited using MaxParallel, a test
+// case that times out releases its place; an abandoned test case may then still
+// be running alongside the MaxParallel test cases that follow.
+//
+// If the duration is negative, the test fails as invalid.
+func Timeout[T any](d time.Duration) testcase.Registration[T] {
+	if d < 0 {
+		GetT().Helper()
+		test.Invalid("Timeout() duration cannot be negative")
+	}
+
+	return func(r *testcase.Runner[T], _ testcase.Flags) {
+		r.SetTimeout(d)
+	}
+}
+
+// MaxParallel limits the number of parallel test cases that run at once,
+// independently of the -parallel flag of go test.  This is useful where test
+// cases share some resource that supports only limited concurrent use, such
+// as a database.
+//
+// The type of the test cases cannot be inferred, so must be specified:
+//
+//	Run(ParallelCases(
+//		ForEach(func(tc testcase) { ... }),
+//		MaxParallel[testcase](4),
+//		Case("first", testcase{ ... }),
+//		Case("second", testcase{ ... }),
+//	))
+//
+// A limit of zero means that there is no limit (the default).  If the limit is
+// negative, the test fails as invalid.
+//
+// A test case that times out (see Timeout) releases its place once it has
+// timed out, so that a test case that never completes does not prevent others
+// from running; the goroutine running the abandoned test case is not counted
+// against the limit, so more than the limit may then be running at once.
+//
+// To limit the number of parallel tests run using ParallelTest() or Parallel(),
+// use the opt.MaxParallel option.
+func MaxParallel[T any](n int) testcase.Registration[T] {
+	if n < 0 {
+		GetT().Helper()
+		test.Invalid("MaxParallel() limit cannot be negative")
+	}
+
+	return func(r *testcase.Runner[T], _ testcase.Flags) {
+		r.SetMaxParallel(n)
+	}
+}
+
+// BeforeAll registers a function to be called before any test cases are run.
+// The function is called in the test frame of the runner.
+//
+// The type of the test cases cannot be inferred from the function, so must
+// be specified:
+//
+//	Run(Testcases(
+//		ForEach(func(tc testcase) { ... }),
+//		BeforeAll[testcase](func() { db = connect() }),
+//		AfterAll[testcase](func() { db.Close() }),
+//		Case("first", testcase{ ... }),
+//	))
+//
+// Multiple functions may be registered; they are called in the order in which
+// they are registered.
+func BeforeAll[T any](fn func()) testcase.Registration[T] {
+	if fn == nil {
+		GetT().Helper()
+		test.Invalid("BeforeAll() function cannot be nil")
+	}
+	return func(r *testcase.Runner[T], _ testcase.Flags) {
+		r.BeforeAll(fn)
+	}
+}
+
+// AfterAll registers a function to be called after all test cases have been
+// run.  The function is called in the test frame of the runner; if any test
+// cases are run in parallel, the function is called once those test cases
+// have completed.
+//
+// As for BeforeAll, the type of the test cases must be specified.
+//
+// Multiple functions may be registered; they are called in the order in which
+// they are registered.
+func AfterAll[T any](fn func()) testcase.Registration[T] {
+	if fn == nil {
+		GetT().Helper()
+		test.Invalid("AfterAll() function cannot be nil")
+	}
+	return func(r *testcase.Runner[T], _ testcase.Flags) {
+		r.AfterAll(fn)
+	}
+}
+
+// BeforeEach registers a function to be called before each test case is run.
+// The function is called in the subtest of the test case, so that Expect()
+// and Cleanup() apply to that subtest.  The function is called with the name
+// of the test case and a pointer to the test case data, which may be modified
+// by the function (e.g. to set up a fixture) before the test case is run:
+//
+//	Run(Testcases(
+//		ForEach(func(tc testcase) { ... }),
+//		BeforeEach(func(name string, tc *testcase) {
+//			tc.repo = NewInMemoryRepository()
+//		}),
+//		Case("first", testcase{ ... }),
+//	))
+//
+// If a BeforeEach function fails the test (or panics), the test case is not
+// run and the subtest fails with a report that setup failed.
+//
+// Multiple functions may be registered; they are called in the order in which
+// they are registered.
+func BeforeEach[T any](fn func(name string, tc *T)) testcase.Registration[T] {
+	if fn == nil {
+		GetT().Helper()
+		test.Invalid("BeforeEach() function cannot be nil")
+	}
+	return func(r *testcase.Runner[T], _ testcase.Flags) {
+		r.BeforeEach(fn)
+	}
+}
+
+// AfterEach registers a function to be called after each test case is run.
+// The function is called in the subtest of the test case, with the name of
+// the test case and a pointer to the test case data.  The function is called
+// even if the test case fails or is not run due to a failure in BeforeEach.
+//
+// Multiple functions may be registered; they are called in the order in which
+// they are registered.
+func AfterEach[T any](fn func(name string, tc *T)) testcase.Registration[T] {
+	if fn == nil {
+		GetT().Helper()
+		test.Invalid("AfterEach() function cannot be nil")
+	}
+	return func(r *testcase.Runner[T], _ testcase.Flags) {
+		r.AfterEach(fn)
+	}
+}
+
 // For creates a TestExecutor that uses the provided function to execute
 // each test case. The function is called with the name of the test case and
 // the test case data. This allows for variations in test execution based on
@@ -105,6 +331,89 @@ func ForEach[T any](exec func(T)) TestExecutor[T] {
 	return testcase.NewExecutor[T](exec)
 }
 
+// ForEachResult creates a TestExecutor that uses the provided function to
+// execute each test case, returning a result and an error.  The result, the
+// error and any panic are then tested against the expected outcome specified
+// by fields of the test case with conventional names:
+//
+//	result/Result/want/Want            // the expected result
+//	err/Err/wantErr/WantErr            // the expected error
+//	panic/Panic/wantPanic/WantPanic    // the expected panic
+//
+// The result is tested using DeepEqual() if the test case has a result field;
+// the type of the field must be compatible with the result of the function.
+//
+// The error is tested using Is(), i.e. errors.Is() for a non-nil expected
+// error.  If the test case has a bool error field, the test instead expects
+// any error if the field is true or no error if false.  If the test case has
+// no error field, no error is expected.
+//
+// If the test case has a panic field with a non-zero value the test expects a
+// panic, tested as for Expect(Panic(..)).DidOccur(); the result and error are
+// not tested.  A bool panic field that is true expects any panic.
+//
+//	type testcase struct {
+//		input  string
+//		result int
+//		err    error
+//		panic  any
+//	}
+//	Run(Testcases(
+//		ForEachResult(func(tc testcase) (int, error) {
+//			return strconv.Atoi(tc.input)
+//		}),
+//		Case("valid", testcase{input: "42", result: 42}),
+//		Case("invalid", testcase{input: "x", err: strconv.ErrSyntax}),
+//	))
+func ForEachResult[T any, R any](exec func(T) (R, error)) TestExecutor[T] {
+	if exec == nil {
+		GetT().Helper()
+		test.Invalid("ForEachResult() function cannot be nil")
+	}
+
+	return testcase.NewExecutor[T](func(tc T) {
+		GetT().Helper()
+
+		want := testcase.ExpectedOutcome(tc)
+		switch {
+		case want.HasPanic && want.Panic == nil:
+			defer Expect(Panic()).DidOccur()
+		case want.HasPanic:
+			defer Expect(Panic(want.Panic)).DidOccur()
+		}
+
+		got, err := exec(tc)
+		if want.HasPanic {
+			return
+		}
+
+		switch wantErr := want.Err.(type) {
+		case nil:
+			Expect(err, "error").IsNil()
+		case bool:
+			if wantErr {
+				Expect(err, "error").IsNotNil()
+			} else {
+				Expect(err, "error").IsNil()
+			}
+		case error:
+			Expect(err, "error").Is(wantErr)
+		default:
+			test.Invalid(fmt.Sprintf("ForEachResult(): error field must be an error or bool: got %T", want.Err))
+		}
+
+		if !want.HasResult {
+			return
+		}
+
+		wantResult, ok := want.Result.(R)
+		if !ok && want.Result != nil {
+			test.Invalid(fmt.Sprintf("ForEachResult(): result field of type %T is not compatible with result of type %T", want.Result, got))
+		}
+		Expect(got, "result").To(DeepEqual(wantResult))
+	})
+}
+
 // ParallelCases creates a Runner to run a set of test cases in parallel.
 //
 // Aside from the parallel execution of the test cases, this function
@@ -1,8 +1,6 @@
 package test
 
 import (
-	"testing"
-
 	"github.com/blugnu/test/internal/testframe"
 )
 
@@ -11,7 +9,7 @@ type Runnable interface {
 }
 
 func Run(r Runnable) {
-	t, ok := testframe.Peek[*testing.T]()
+	t, ok := testframe.Peek[TestingT]()
 	if !ok {
 		panic("ERROR: test.Run() must be called from a Test..(*testing.T) func; it is not supported in Example..() funcs")
 	}
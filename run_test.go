@@ -1,6 +1,9 @@
 package test_test
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 
 	. "github.com/blugnu/test"
@@ -240,3 +243,349 @@ func TestRun_Testcases(t *testing.T) {
 		result.ExpectWarning("all cases were skipped")
 	}))
 }
+
+func TestRun_Testcases_Groups(t *testing.T) {
+	With(t)
+
+	type tc struct {
+		a, b   int
+		result int
+	}
+
+	Run(Test("nested groups", func() {
+		names := []string{}
+
+		Run(Testcases(
+			For(func(name string, tc tc) {
+				names = append(names, T().Name())
+				Expect(tc.a + tc.b).To(Equal(tc.result))
+			}),
+			Case("ungrouped", tc{a: 1, b: 1, result: 2}),
+			Group("positive",
+				Case("small", tc{a: 1, b: 2, result: 3}),
+				Group("large",
+					Case("thousands", tc{a: 1000, b: 2000, result: 3000}),
+				),
+			),
+			Group("negative",
+				Case("small", tc{a: -1, b: -2, result: -3}),
+			),
+			Group("positive",
+				Case("zero", tc{a: 0, b: 0, result: 0}),
+			),
+		))
+
+		Expect(names).To(EqualSlice([]string{
+			"TestRun_Testcases_Groups/nested_groups/ungrouped",
+			"TestRun_Testcases_Groups/nested_groups/positive/small",
+			"TestRun_Testcases_Groups/nested_groups/positive/large/thousands",
+			"TestRun_Testcases_Groups/nested_groups/positive/zero",
+			"TestRun_Testcases_Groups/nested_groups/negative/small",
+		}))
+	}))
+
+	Run(Test("skip group", func() {
+		names := []string{}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc tc) {
+					names = append(names, name)
+				}),
+				Case("ungrouped", tc{}),
+				SkipGroup("skipped",
+					Case("first", tc{}),
+					Group("nested",
+						Case("second", tc{}),
+						Case("third", tc{}),
+					),
+				),
+			))
+		})
+
+		Expect(names).To(EqualSlice([]string{"ungrouped"}))
+		result.ExpectWarning("3 of 4 cases were skipped")
+
+		// warnings are reported only once, for the runner as a whole
+		Expect(result.FailedTests).To(EqualSlice([]string{
+			"TestRun_Testcases_Groups/skip_group",
+		}))
+	}))
+
+	Run(Test("group summary", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc tc) {
+					Expect(tc.a + tc.b).To(Equal(tc.result))
+				}),
+				Group("group",
+					Case("fails", tc{a: 1, b: 1, result: 3}),
+					Skip("skipped", tc{}),
+				),
+			))
+		})
+
+		// the summary for the group is logged in the subtest of the group (output
+		// here since that subtest failed) with a warning reported by the runner
+		report := strings.Join(result.Report, "\n")
+		Expect(strings.Count(report, "1 of 2 cases were skipped")).To(Equal(2))
+		result.ExpectWarning("1 of 2 cases were skipped")
+	}))
+
+	Run(Test("debug group", func() {
+		names := []string{}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc tc) {
+					names = append(names, name)
+				}),
+				Case("ungrouped", tc{}),
+				DebugGroup("debugged",
+					Case("first", tc{}),
+				),
+				Group("other",
+					Case("second", tc{}),
+				),
+			))
+		})
+
+		Expect(names).To(EqualSlice([]string{"first"}))
+		result.ExpectWarning("only 1 of 3 cases were evaluated (debug mode)")
+		Expect(result.FailedTests).To(EqualSlice([]string{
+			"TestRun_Testcases_Groups/debug_group",
+		}))
+	}))
+
+	Run(Test("debug case in skip group", func() {
+		names := []string{}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				For(func(name string, tc tc) {
+					names = append(names, name)
+				}),
+				SkipGroup("skipped",
+					Case("first", tc{}),
+					Debug("second", tc{}),
+				),
+			))
+		})
+
+		Expect(names).To(EqualSlice([]string{"second"}))
+		result.ExpectWarning("only 1 of 2 cases were evaluated (debug mode)")
+	}))
+
+	Run(Test("parallel group", func() {
+		result := TestHelper(func() {
+			Run(ParallelCases(
+				ForEach(func(tc tc) {
+					Expect(IsParallel()).To(BeTrue())
+				}),
+				Group("group",
+					Case("first", tc{}),
+					Case("second", tc{}),
+				),
+			))
+		})
+
+		result.Expect(TestPassed)
+	}))
+}
+
+func TestRun_Testcases_ForEachResult(t *testing.T) {
+	With(t)
+
+	errFailed := errors.New("failed")
+
+	type tc struct {
+		input  string
+		result int
+		err    error
+		panic  any
+	}
+
+	atoi := func(tc tc) (int, error) {
+		if tc.input == "panic" {
+			panic(errFailed)
+		}
+		return strconv.Atoi(tc.input)
+	}
+
+	Run(Test("ForEachResult(nil)", func() {
+		result := TestHelper(func() {
+			ForEachResult[tc, int](nil)
+		})
+
+		result.ExpectInvalid("ForEachResult() function cannot be nil")
+	}))
+
+	Run(Test("expected outcomes", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(atoi),
+				Case("result", tc{input: "42", result: 42}),
+				Case("error", tc{input: "x", err: strconv.ErrSyntax}),
+				Case("panic", tc{input: "panic", panic: errFailed}),
+			))
+		})
+
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("wrong result", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(atoi),
+				Case("wrong result", tc{input: "42", result: 41}),
+			))
+		})
+
+		result.Expect(
+			"result:",
+			"  expected 41, got 42",
+		)
+	}))
+
+	Run(Test("wrong error", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(atoi),
+				Case("wrong error", tc{input: "x", err: errFailed}),
+			))
+		})
+
+		result.Expect(
+			"expected error: failed",
+			"got           : strconv.Atoi: parsing \"x\": invalid syntax",
+		)
+	}))
+
+	Run(Test("unexpected error", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(atoi),
+				Case("unexpected error", tc{input: "x"}),
+			))
+		})
+
+		result.Expect(
+			"error:",
+			"  expected nil, got error: strconv.Atoi: parsing \"x\": invalid syntax",
+		)
+	}))
+
+	Run(Test("expected panic did not occur", func() {
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(atoi),
+				Case("no panic", tc{input: "1", panic: errFailed}),
+			))
+		})
+
+		result.Expect(
+			"expected panic: *errors.errorString(failed)",
+			"  recovered   : nil (did not panic)",
+		)
+	}))
+
+	Run(Test("bool error field", func() {
+		type tc struct {
+			input   string
+			want    int
+			wantErr bool
+		}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(func(tc tc) (int, error) { return strconv.Atoi(tc.input) }),
+				Case("no error", tc{input: "1", want: 1}),
+				Case("any error", tc{input: "x", wantErr: true}),
+			))
+		})
+
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("typed panic fields", func() {
+		type withString struct {
+			input string
+			want  int
+			panic string
+		}
+		type withBool struct {
+			input     string
+			want      int
+			wantPanic bool
+		}
+
+		atoi := func(input string) (int, error) {
+			if input == "panic" {
+				panic("boom")
+			}
+			return strconv.Atoi(input)
+		}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(func(tc withString) (int, error) { return atoi(tc.input) }),
+				Case("no panic (zero value)", withString{input: "1", want: 1}),
+				Case("panic value", withString{input: "panic", panic: "boom"}),
+			))
+			Run(Testcases(
+				ForEachResult(func(tc withBool) (int, error) { return atoi(tc.input) }),
+				Case("no panic (zero value)", withBool{input: "1", want: 1}),
+				Case("any panic", withBool{input: "panic", wantPanic: true}),
+			))
+		})
+
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("no expected result", func() {
+		type tc struct {
+			input string
+		}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(func(tc tc) (int, error) { return strconv.Atoi(tc.input) }),
+				Case("result not tested", tc{input: "1"}),
+			))
+		})
+
+		result.Expect(TestPassed)
+	}))
+
+	Run(Test("incompatible result field", func() {
+		type tc struct {
+			input  string
+			result string
+		}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(func(tc tc) (int, error) { return strconv.Atoi(tc.input) }),
+				Case("incompatible", tc{input: "1"}),
+			))
+		})
+
+		result.ExpectInvalid("ForEachResult(): result field of type string is not compatible with result of type int")
+	}))
+
+	Run(Test("invalid error field", func() {
+		type tc struct {
+			input string
+			err   string
+		}
+
+		result := TestHelper(func() {
+			Run(Testcases(
+				ForEachResult(func(tc tc) (int, error) { return strconv.Atoi(tc.input) }),
+				Case("invalid", tc{input: "1"}),
+			))
+		})
+
+		result.ExpectInvalid("ForEachResult(): error field must be an error or bool: got string")
+	}))
+}
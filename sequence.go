@@ -0,0 +1,166 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SequenceStep is an interface implemented by expected calls to a mock function
+// and by mock functions themselves, allowing them to be steps in a Sequence.
+//
+// The interface has unexported methods; it cannot be implemented outside of
+// this package.
+type SequenceStep interface {
+	// addToSequence makes the step the idx'th step in a sequence, returning
+	// whether the step is initially complete and a description of the step
+	addToSequence(seq *Sequence, idx int) (bool, string)
+}
+
+// Sequence is an ordered sequence of expected calls and/or mock functions,
+// established using InOrder.
+//
+// The sequence maintains the state of each step, updated as calls are recorded
+// by the mock functions involved.  This avoids the need for the sequence to
+// query the state of (and therefore lock) mock functions other than the one
+// recording a call, which could deadlock when mock functions are called
+// concurrently.
+type Sequence struct {
+	mu sync.Mutex
+
+	// complete indicates whether each step in the sequence is complete
+	complete []bool
+
+	// desc is a description of the state of each step in the sequence
+	desc []string
+}
+
+// InOrder establishes a sequence in which calls to expected calls and/or mock
+// functions must be made.  Steps in the sequence may be expected calls or mock
+// functions of any type, e.g. to test that a call to a Begin function is made
+// before a call to an Exec function, followed by a call to a Commit function:
+//
+//	begin := MockFn[any, any]{}
+//	exec := MockFn[string, int]{}
+//	commit := MockFn[any, any]{}
+//
+//	InOrder(
+//		begin.ExpectCall(),
+//		exec.ExpectCall().WithArgs("INSERT ..."),
+//		commit.ExpectCall(),
+//	)
+//
+// A step that is an expected call is complete when the call has been made the
+// minimum number of times expected.  A step that is a mock function is complete
+// when all expected calls of that function are complete or, for a mock function
+// with no expected calls (e.g. one configured for mapped results), once any
+// call has been made.
+//
+// A call matched with a step in the sequence is out of order if any preceding
+// step is not complete; the call is then recorded with an ErrOutOfOrder error
+// (which is also returned by the call).
+//
+// An expected call or mock function may be a step in only one sequence; if any
+// step is already in a sequence, InOrder panics with ErrInvalidOperation.
+func InOrder(steps ...SequenceStep) *Sequence {
+	if len(steps) < 2 {
+		panic(fmt.Errorf("InOrder: %w: at least two steps are required", ErrInvalidArgument))
+	}
+
+	seq := &Sequence{
+		complete: make([]bool, len(steps)),
+		desc:     make([]string, len(steps)),
+	}
+	for i, step := range steps {
+		seq.complete[i], seq.desc[i] = step.addToSequence(seq, i)
+	}
+	return seq
+}
+
+// record updates the state of a step in the sequence following a call matched
+// with that step, returning an error if the call was made before all preceding
+// steps were complete; otherwise nil.
+//
+// The receiver may be nil (for a step that is not in a sequence), in which case
+// the result is nil.
+func (seq *Sequence) record(idx int, complete bool, desc string) error {
+	if seq == nil {
+		return nil
+	}
+
+	seq.mu.Lock()
+	defer seq.mu.Unlock()
+
+	seq.complete[idx] = complete
+	seq.desc[idx] = desc
+
+	for i := 0; i < idx; i++ {
+		if !seq.complete[i] {
+			return fmt.Errorf("%w: step %d of %d made before step %d was complete (%s)",
+				ErrOutOfOrder,
+				idx+1, len(seq.complete),
+				i+1, seq.desc[i],
+			)
+		}
+	}
+	return nil
+}
+
+func (mock *mockFnCall[A, R]) addToSequence(seq *Sequence, idx int) (bool, string) {
+	if mock.seq != nil {
+		panic(fmt.Errorf("InOrder: %w: expected call is already in a sequence", ErrInvalidOperation))
+	}
+	mock.seq = seq
+	mock.seqIdx = idx
+
+	return mock.isSatisfied(), mock.describe()
+}
+
+func (mock *MockFn[A, R]) addToSequence(seq *Sequence, idx int) (bool, string) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	if mock.seq != nil {
+		panic(fmt.Errorf("InOrder: %w: mock function is already in a sequence", ErrInvalidOperation))
+	}
+	mock.seq = seq
+	mock.seqIdx = idx
+
+	return mock.isStepSatisfied(), mock.describeStep()
+}
+
+// recordStep updates the state of the mock function as a step in a sequence
+// (if any) following a call, returning an error if the call was made before
+// all preceding steps were complete; the caller must hold the lock on the mock
+func (mock *MockFn[A, R]) recordStep() error {
+	if mock.seq == nil {
+		return nil
+	}
+	return mock.seq.record(mock.seqIdx, mock.isStepSatisfied(), mock.describeStep())
+}
+
+// isStepSatisfied returns true if all expected calls of the mock function have
+// been made the minimum number of times expected.  If the mock function has no
+// expected calls, the step is satisfied once any call has been made.
+func (mock *MockFn[A, R]) isStepSatisfied() bool {
+	if len(mock.expectations) == 0 {
+		return len(mock.actual) > 0
+	}
+
+	for _, ex := range mock.expectations {
+		if !ex.isSatisfied() {
+			return false
+		}
+	}
+	return true
+}
+
+// describeStep describes the first expected call of the mock function that has
+// not been made the minimum number of times expected
+func (mock *MockFn[A, R]) describeStep() string {
+	for _, ex := range mock.expectations {
+		if !ex.isSatisfied() {
+			return fmt.Sprintf("%T: %s", mock, ex.describe())
+		}
+	}
+	return fmt.Sprintf("%T: no calls made", mock)
+}
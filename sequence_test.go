@@ -0,0 +1,191 @@
+package test //nolint:testpackage // tests private functions and types
+
+import (
+	"testing"
+)
+
+func TestInOrder(t *testing.T) {
+	With(t)
+
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func()
+	}{
+		{scenario: "calls made in order",
+			exec: func() {
+				// ARRANGE
+				begin := MockFn[any, any]{}
+				exec := MockFn[string, int]{}
+				commit := MockFn[any, any]{}
+				InOrder(
+					begin.ExpectCall(),
+					exec.ExpectCall().WithArgs("INSERT"),
+					commit.ExpectCall(),
+				)
+
+				// ACT
+				_, err1 := begin.RecordCall()
+				_, err2 := exec.RecordCall("INSERT")
+				_, err3 := commit.RecordCall()
+
+				// ASSERT
+				Expect(err1).IsNil()
+				Expect(err2).IsNil()
+				Expect(err3).IsNil()
+			},
+		},
+		{scenario: "call made out of order",
+			exec: func() {
+				// ARRANGE
+				begin := MockFn[any, any]{}
+				exec := MockFn[string, int]{}
+				commit := MockFn[any, any]{}
+				InOrder(
+					begin.ExpectCall(),
+					exec.ExpectCall().WithArgs("INSERT"),
+					commit.ExpectCall(),
+				)
+
+				// ACT
+				_, err1 := begin.RecordCall()
+				_, err2 := commit.RecordCall()
+				_, err3 := exec.RecordCall("INSERT")
+
+				// ASSERT
+				Expect(err1).IsNil()
+				Expect(err2).Is(ErrOutOfOrder)
+				Expect(err2.Error()).To(ContainString("step 3 of 3 made before step 2 was complete (expected 1 call with args INSERT, got 0)"))
+				Expect(err3).IsNil()
+				Expect(commit.ExpectationsWereMet()).Is(ErrOutOfOrder)
+			},
+		},
+		{scenario: "mock functions as steps",
+			exec: func() {
+				// ARRANGE
+				begin := MockFn[any, any]{}
+				begin.ExpectCall()
+				exec := MockFn[int, int]{}
+				exec.ExpectCall().WithArgs(1)
+				exec.ExpectCall().WithArgs(2)
+				InOrder(&begin, &exec)
+
+				// ACT
+				_, err1 := exec.RecordCall(1)
+				_, err2 := begin.RecordCall()
+				_, err3 := exec.RecordCall(2)
+
+				// ASSERT
+				Expect(err1).Is(ErrOutOfOrder)
+				Expect(err1.Error()).To(ContainString("step 2 of 2 made before step 1 was complete (*test.MockFn[interface {},interface {}]: expected 1 call, got 0)"))
+				Expect(err2).IsNil()
+				Expect(err3).IsNil()
+			},
+		},
+		{scenario: "mock function with mapped results as a step",
+			exec: func() {
+				// ARRANGE
+				lookup := MockFn[string, int]{}
+				lookup.WhenCalledWith("key").Returns(42)
+				commit := MockFn[any, any]{}
+				InOrder(&lookup, commit.ExpectCall())
+
+				// ACT
+				result, err1 := lookup.RecordCall("key")
+				_, err2 := commit.RecordCall()
+
+				// ASSERT
+				Expect(result).To(Equal(42))
+				Expect(err1).IsNil()
+				Expect(err2).IsNil()
+				Expect(lookup.ExpectationsWereMet()).IsNil()
+				Expect(commit.ExpectationsWereMet()).IsNil()
+			},
+		},
+		{scenario: "mock function with mapped results called out of order",
+			exec: func() {
+				// ARRANGE
+				begin := MockFn[any, any]{}
+				lookup := MockFn[string, int]{}
+				lookup.WhenCalledWith("key").Returns(42)
+				InOrder(begin.ExpectCall(), &lookup)
+
+				// ACT
+				_, err1 := lookup.RecordCall("key")
+				_, err2 := begin.RecordCall()
+				result, err3 := lookup.RecordCall("key")
+
+				// ASSERT
+				Expect(err1).Is(ErrOutOfOrder)
+				Expect(err1.Error()).To(ContainString("step 2 of 2 made before step 1 was complete (expected 1 call, got 0)"))
+				Expect(err2).IsNil()
+				Expect(result).To(Equal(42))
+				Expect(err3).IsNil()
+				Expect(lookup.ExpectationsWereMet()).Is(ErrOutOfOrder)
+			},
+		},
+		{scenario: "repeated calls",
+			exec: func() {
+				// ARRANGE
+				open := MockFn[any, any]{}
+				read := MockFn[any, any]{}
+				closer := MockFn[any, any]{}
+				InOrder(
+					open.ExpectCall(),
+					read.ExpectCall().AtLeast(1),
+					closer.ExpectCall(),
+				)
+
+				// ACT
+				_, err1 := open.RecordCall()
+				_, err2 := read.RecordCall()
+				_, err3 := read.RecordCall()
+				_, err4 := closer.RecordCall()
+
+				// ASSERT
+				Expect(err1).IsNil()
+				Expect(err2).IsNil()
+				Expect(err3).IsNil()
+				Expect(err4).IsNil()
+			},
+		},
+		{scenario: "too few steps",
+			exec: func() {
+				// ARRANGE + ASSERT
+				fn := MockFn[any, any]{}
+				defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+				// ACT
+				InOrder(fn.ExpectCall())
+			},
+		},
+		{scenario: "step already in a sequence",
+			exec: func() {
+				// ARRANGE + ASSERT
+				fn := MockFn[any, any]{}
+				a, b, c := fn.ExpectCall(), fn.ExpectCall(), fn.ExpectCall()
+				InOrder(a, b)
+				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+				// ACT
+				InOrder(b, c)
+			},
+		},
+		{scenario: "mock function already in a sequence",
+			exec: func() {
+				// ARRANGE + ASSERT
+				a, b := MockFn[any, any]{}, MockFn[any, any]{}
+				InOrder(&a, &b)
+				defer Expect(Panic(ErrInvalidOperation)).DidOccur()
+
+				// ACT
+				InOrder(&b, &a)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		Run(Test(tc.scenario, func() {
+			tc.exec()
+		}))
+	}
+}
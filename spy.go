@@ -0,0 +1,156 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/blugnu/test/matchers/matcher"
+	"github.com/blugnu/test/matchers/mocks"
+)
+
+// SpyFn is a spy on a function accepting an argument of type A and returning
+// a result of type R and an error.  Calls to the spy are passed through to the
+// function, with the arguments, result, error and duration of each call being
+// recorded.
+//
+// A SpyFn is created using Spy(); it is safe for concurrent use.
+type SpyFn[A any, R any] struct {
+	mu    sync.Mutex
+	fn    func(A) (R, error)
+	calls []SpyCall[A, R]
+}
+
+// SpyCall is a call recorded by a spy.
+type SpyCall[A any, R any] struct {
+	Args     A
+	Result   R
+	Err      error
+	Duration time.Duration
+}
+
+// Spy returns a spy on a function.  Unlike a mock, a spy does not replace the
+// behaviour of the function; the spy is used in place of the function (e.g.
+// the Call method of the spy is injected into the code under test) and calls
+// are passed through to the function and recorded.
+//
+// Expectations of the calls made may then be tested using the spy matchers,
+// HaveBeenCalled(), HaveBeenCalledTimes() and HaveBeenCalledWith(), or by
+// inspecting the calls recorded by the spy:
+//
+//	repo := NewInMemoryRepository()
+//	get := Spy(repo.Get)
+//	svc := NewService(get.Call)
+//
+//	// .. call the code under test
+//
+//	Expect(get).Should(HaveBeenCalledWith(Equal("user-1")))
+//
+// If the function accepts multiple arguments and/or returns multiple result
+// values (in addition to an error) it must be adapted to a function accepting
+// and returning struct types, as for a MockFn.
+//
+// If the function is nil, Spy panics with ErrInvalidArgument.
+func Spy[A any, R any](fn func(A) (R, error)) *SpyFn[A, R] {
+	if fn == nil {
+		panic(fmt.Errorf("Spy: %w: function is nil", ErrInvalidArgument))
+	}
+	return &SpyFn[A, R]{fn: fn}
+}
+
+// Call calls the function being spied on, recording the call and returning
+// the result and error returned by the function.
+//
+// If the function panics the call is recorded with zero values for the result
+// and error, and the panic is not recovered.
+func (spy *SpyFn[A, R]) Call(args A) (result R, err error) {
+	start := time.Now()
+	defer func() {
+		spy.mu.Lock()
+		defer spy.mu.Unlock()
+
+		spy.calls = append(spy.calls, SpyCall[A, R]{
+			Args:     args,
+			Result:   result,
+			Err:      err,
+			Duration: time.Since(start),
+		})
+	}()
+
+	return spy.fn(args)
+}
+
+// Calls returns a copy of the calls recorded by the spy, in the order in which
+// they were made.
+func (spy *SpyFn[A, R]) Calls() []SpyCall[A, R] {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	result := make([]SpyCall[A, R], len(spy.calls))
+	copy(result, spy.calls)
+	return result
+}
+
+// CallCount returns the number of calls recorded by the spy.
+func (spy *SpyFn[A, R]) CallCount() int {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	return len(spy.calls)
+}
+
+// Args returns the arguments of the calls recorded by the spy, in the order in
+// which they were made.
+func (spy *SpyFn[A, R]) Args() []A {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	result := make([]A, len(spy.calls))
+	for i, c := range spy.calls {
+		result[i] = c.Args
+	}
+	return result
+}
+
+// Reset removes all calls recorded by the spy.
+func (spy *SpyFn[A, R]) Reset() {
+	spy.mu.Lock()
+	defer spy.mu.Unlock()
+
+	spy.calls = nil
+}
+
+// HaveBeenCalled returns a matcher that tests whether a spy has recorded any
+// calls:
+//
+//	Expect(spy).Should(HaveBeenCalled())
+//	Expect(spy).ShouldNot(HaveBeenCalled())
+//
+// If the value tested is not a spy, the test fails as invalid.
+func HaveBeenCalled() mocks.CalledMatcher {
+	return mocks.CalledMatcher{}
+}
+
+// HaveBeenCalledTimes returns a matcher that tests whether a spy has recorded
+// a specified number of calls:
+//
+//	Expect(spy).Should(HaveBeenCalledTimes(2))
+//
+// If the value tested is not a spy, the test fails as invalid.
+func HaveBeenCalledTimes(n int) mocks.CalledTimesMatcher {
+	return mocks.CalledTimesMatcher{Expected: n}
+}
+
+// HaveBeenCalledWith returns a matcher that tests whether a spy has recorded
+// any call with arguments satisfying a specified matcher:
+//
+//	Expect(spy).Should(HaveBeenCalledWith(Equal("user-1")))
+//
+// The type of value tested by the matcher must be the argument type of the
+// spy; if not, or if the value tested is not a spy, the test fails as invalid.
+func HaveBeenCalledWith[A any](m matcher.ForType[A]) mocks.CalledWithMatcher[A] {
+	if m == nil {
+		panic(fmt.Errorf("HaveBeenCalledWith: %w: a matcher must be specified", ErrInvalidArgument))
+	}
+	return mocks.CalledWithMatcher[A]{Matcher: m}
+}
@@ -0,0 +1,116 @@
+package test_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/blugnu/test"
+)
+
+func TestSpy(t *testing.T) {
+	With(t)
+
+	errOdd := errors.New("odd")
+	half := func(n int) (int, error) {
+		if n%2 != 0 {
+			return 0, errOdd
+		}
+		return n / 2, nil
+	}
+
+	Run(Test("nil function", func() {
+		defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+		Spy[int, int](nil)
+	}))
+
+	Run(Test("calls are passed through and recorded", func() {
+		// ARRANGE
+		spy := Spy(half)
+
+		// ACT
+		result, err := spy.Call(4)
+		_, oddErr := spy.Call(3)
+
+		// ASSERT
+		Expect(result).To(Equal(2))
+		Expect(err).IsNil()
+		Expect(oddErr).Is(errOdd)
+
+		calls := spy.Calls()
+		Expect(calls).Should(HaveLen(2))
+		Expect(calls[0].Args).To(Equal(4))
+		Expect(calls[0].Result).To(Equal(2))
+		Expect(calls[0].Err).IsNil()
+		Expect(calls[1].Args).To(Equal(3))
+		Expect(calls[1].Err).Is(errOdd)
+		Expect(spy.Args()).To(EqualSlice([]int{4, 3}))
+		Expect(spy.CallCount()).To(Equal(2))
+	}))
+
+	Run(Test("call duration is recorded", func() {
+		// ARRANGE
+		spy := Spy(func(d time.Duration) (any, error) {
+			time.Sleep(d)
+			return nil, nil
+		})
+
+		// ACT
+		_, _ = spy.Call(10 * time.Millisecond)
+
+		// ASSERT
+		Expect(spy.Calls()[0].Duration >= 10*time.Millisecond, "duration").Is(true)
+	}))
+
+	Run(Test("panicking call is recorded", func() {
+		// ARRANGE
+		spy := Spy(func(int) (int, error) { panic("boom") })
+		defer func() {
+			_ = recover()
+			Expect(spy.CallCount()).To(Equal(1))
+		}()
+
+		// ACT
+		_, _ = spy.Call(1)
+	}))
+
+	Run(Test("reset", func() {
+		// ARRANGE
+		spy := Spy(half)
+		_, _ = spy.Call(2)
+
+		// ACT
+		spy.Reset()
+
+		// ASSERT
+		Expect(spy).ShouldNot(HaveBeenCalled())
+	}))
+
+	Run(Test("concurrent calls", func() {
+		// ARRANGE
+		spy := Spy(half)
+		wg := sync.WaitGroup{}
+
+		// ACT
+		for i := 0; i < 10; i++ {
+			n := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = spy.Call(n)
+			}()
+		}
+		wg.Wait()
+
+		// ASSERT
+		Expect(spy).Should(HaveBeenCalledTimes(10))
+	}))
+
+	Run(Test("nil matcher", func() {
+		defer Expect(Panic(ErrInvalidArgument)).DidOccur()
+
+		HaveBeenCalledWith[int](nil)
+	}))
+}
@@ -15,9 +15,56 @@ func ContainString(expected string) strings.ContainsMatch {
 	return strings.ContainsMatch{Expected: expected}
 }
 
+// MatchRegEx returns a matcher that checks that a string contains a match
+// for a regular expression.
+//
+// The values captured by groups in the expression may also be tested, using
+// the WithGroup method of the returned matcher to apply other matchers to the
+// value captured by a named (or numbered) group, e.g.:
+//
+//	Expect(s).To(MatchRegEx(`id=(?P<id>\d+)`).WithGroup("id", Equal("42")))
+//
+// If the string does not contain a match, the failure report identifies the
+// longest part of the string matched by a prefix of the expression, to help
+// identify where the expression failed to match.
+//
+// # Supported Options
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
 func MatchRegEx(regex string) strings.RegExMatch {
+	return strings.RegExMatch{Expected: compileRegEx("MatchRegEx", regex)}
+}
+
+// MatchRegExAll returns a matcher that checks that a string contains a
+// specified number of (non-overlapping) matches for a regular expression.
+//
+// The count must not be negative; a count of zero may be used to test that
+// a string contains no matches.
+//
+// # Supported Options
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
+func MatchRegExAll(regex string, n int) strings.RegExCountMatch {
+	if n < 0 {
+		panic(fmt.Errorf("MatchRegExAll: %w: count must not be negative (got %d)", ErrInvalidArgument, n))
+	}
+
+	return strings.RegExCountMatch{Expected: compileRegEx("MatchRegExAll", regex), Count: n}
+}
+
+// compileRegEx compiles a regular expression for a regex matcher, panicking
+// with ErrInvalidArgument if the expression is empty or invalid.
+func compileRegEx(fn string, regex string) *regexp.Regexp {
 	if regex == "" {
-		panic(fmt.Errorf("Match: %w: empty string is not valid; a valid regex must be provided", ErrInvalidArgument))
+		panic(fmt.Errorf("%s: %w: empty string is not valid; a valid regex must be provided", fn, ErrInvalidArgument))
 	}
 
 	ex, err := regexp.Compile(regex)
@@ -25,5 +72,125 @@ func MatchRegEx(regex string) strings.RegExMatch {
 		panic(fmt.Errorf("invalid regex: %w: %w", ErrInvalidArgument, err))
 	}
 
-	return strings.RegExMatch{Expected: ex}
+	return ex
+}
+
+// HavePrefix returns a matcher that checks that a string begins with an
+// expected prefix.
+//
+// If the test fails, the failure report identifies the first character
+// in the string that differs from the expected prefix.
+//
+// # Supported Options
+//
+//	opt.CaseSensitive(bool)    // determines whether the comparison is case
+//	                           // sensitive (default is true)
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
+func HavePrefix(prefix string) strings.PrefixMatch {
+	if prefix == "" {
+		panic(fmt.Errorf("HavePrefix: %w: empty string is not valid", ErrInvalidArgument))
+	}
+
+	return strings.PrefixMatch{Expected: prefix}
+}
+
+// HaveSuffix returns a matcher that checks that a string ends with an
+// expected suffix.
+//
+// If the test fails, the failure report identifies the last character
+// in the string that differs from the expected suffix.
+//
+// # Supported Options
+//
+//	opt.CaseSensitive(bool)    // determines whether the comparison is case
+//	                           // sensitive (default is true)
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
+func HaveSuffix(suffix string) strings.SuffixMatch {
+	if suffix == "" {
+		panic(fmt.Errorf("HaveSuffix: %w: empty string is not valid", ErrInvalidArgument))
+	}
+
+	return strings.SuffixMatch{Expected: suffix}
+}
+
+// EqualFold returns a matcher that checks that a string is equal to an
+// expected string, ignoring differences in case.
+//
+// This is equivalent to strings.EqualFold(got, expected).
+//
+// # Supported Options
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
+func EqualFold(expected string) strings.EqualFoldMatch {
+	return strings.EqualFoldMatch{Expected: expected}
+}
+
+// EqualIgnoringWhitespace returns a matcher that checks that a string is
+// equal to an expected string when leading and trailing whitespace is
+// ignored and any run of whitespace characters (spaces, tabs, newlines etc)
+// is treated as a single space.
+//
+// If the test fails, the failure report presents the strings as specified,
+// identifying the first character in the subject that differs once
+// whitespace has been collapsed.
+//
+// # Supported Options
+//
+//	opt.CaseSensitive(bool)    // determines whether the comparison is case
+//	                           // sensitive (default is true)
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
+func EqualIgnoringWhitespace(expected string) strings.EqualIgnoringWhitespaceMatch {
+	return strings.EqualIgnoringWhitespaceMatch{Expected: expected}
+}
+
+// ContainStringsInOrder returns a matcher that checks that a string contains
+// each of the expected substrings, in the order specified.  Substrings must
+// not overlap but need not be contiguous.
+//
+// If the test fails, the failure report marks the substrings that were found
+// and identifies the first substring that was not.
+//
+// At least one substring must be specified and no substring may be empty.
+//
+// # Supported Options
+//
+//	opt.CaseSensitive(bool)    // determines whether the comparison is case
+//	                           // sensitive (default is true)
+//
+//	opt.QuotedStrings(bool)    // determines whether strings are quoted in the
+//	                           // test failure report (quoted by default)
+//
+//	opt.FailureReport(func)    // a function returning a custom failure report
+//	                           // in the event that the test fails
+func ContainStringsInOrder(expected ...string) strings.ContainsInOrderMatch {
+	if len(expected) == 0 {
+		panic(fmt.Errorf("ContainStringsInOrder: %w: at least one string must be specified", ErrInvalidArgument))
+	}
+
+	for i, s := range expected {
+		if s == "" {
+			panic(fmt.Errorf("ContainStringsInOrder: %w: empty string is not valid (at index %d)", ErrInvalidArgument, i))
+		}
+	}
+
+	return strings.ContainsInOrderMatch{Expected: expected}
 }
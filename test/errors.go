@@ -1,7 +1,14 @@
 package test
 
 import (
+	"errors"
+
 	"github.com/blugnu/test/internal/testframe"
 )
 
 var ErrNoTestFrame = testframe.ErrNoTestFrame
+
+// ErrInvalidArgument is the error with which functions panic when called with
+// an invalid argument.  It is declared here so that it may be used by matchers
+// that cannot import the test package itself.
+var ErrInvalidArgument = errors.New("invalid argument")
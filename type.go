@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/blugnu/test/matchers/types"
 	"github.com/blugnu/test/opt"
 	"github.com/blugnu/test/test"
 )
@@ -19,7 +20,7 @@ func ExpectType[T any](got any, opts ...any) (T, bool) {
 	expectedType := reflect.TypeOf(z)
 
 	if fmt.Sprintf("%s", expectedType) == "%!s(<nil>)" {
-		test.Invalid("ExpectType: cannot be used to test for interfaces")
+		test.Invalid("ExpectType: cannot be used to test for interfaces (use ExpectImplements)")
 		return z, false
 	}
 
@@ -47,3 +48,149 @@ func RequireType[T any](got any, opts ...any) T {
 	z, _ := ExpectType[T](got, append(opts, opt.Required())...)
 	return z
 }
+
+// ExpectImplements tests that a value implements an interface I.  If the
+// test passes, the value is returned as I, with true.  If the test fails
+// the zero value of I is returned, with false.
+//
+// If the value does not implement the interface the test failure report
+// identifies the methods that are missing, e.g.:
+//
+//	expected: value assignable to: io.ReadCloser
+//	got     : *bytes.Buffer
+//	missing : Close
+//
+// I must be an interface type; to test for a concrete type use ExpectType.
+func ExpectImplements[I any](got any, opts ...any) (I, bool) {
+	GetT().Helper()
+
+	z := *new(I)
+	if TypeOf[I]().Kind() != reflect.Interface {
+		test.Invalid(fmt.Sprintf("ExpectImplements: %s is not an interface (use ExpectType)", TypeOf[I]()))
+		return z, false
+	}
+
+	Expect(got, opts...).Should(BeAssignableTo[I]())
+
+	result, ok := got.(I)
+	return result, ok
+}
+
+// RequireImplements tests that a value implements an interface I.  If the
+// test passes, the value is returned as I otherwise the test fails
+// immediately without evaluating any further expectations.
+func RequireImplements[I any](got any, opts ...any) I {
+	GetT().Helper()
+
+	z, _ := ExpectImplements[I](got, append(opts, opt.Required())...)
+	return z
+}
+
+// ExpectTypeOneOf tests that a value is of any one of a number of types.
+// If the test passes, the matching type is returned, with true.  If the
+// test fails, nil is returned with false.
+//
+// With opt.ToNotMatch(true) there is no matching type when the test passes,
+// so nil is returned with true; if the value is of any of the types, the
+// test fails and nil is returned with false.
+//
+// Types are specified as a slice of reflect.Type values, most conveniently
+// obtained using TypeOf, e.g.:
+//
+//	ExpectTypeOneOf(got, []reflect.Type{TypeOf[int](), TypeOf[string](), TypeOf[fmt.Stringer]()})
+//
+// A concrete type is matched only by a value of exactly that type.  An
+// interface type is matched by any value that implements the interface.
+// Types are tested in the order specified; the first matching type is
+// returned.
+//
+// If no types are specified, or any of the types is nil, the test is
+// invalid.
+//
+// # Supported Options
+//
+//	string                   // a name for the value, for use in any test
+//	                         // failure report
+//
+//	opt.ToNotMatch(bool)     // if true, the test fails if the value is of any
+//	                         // of the types
+//
+//	opt.FailureReport(func)  // a function returning a custom failure report
+//	                         // in the event that the test fails
+//
+//	opt.OnFailure(string)    // a string to output as the failure report
+//	                         // if the test fails
+func ExpectTypeOneOf(got any, oneOf []reflect.Type, opts ...any) (reflect.Type, bool) {
+	GetT().Helper()
+
+	if len(oneOf) == 0 {
+		test.Invalid("ExpectTypeOneOf: no types specified")
+		return nil, false
+	}
+
+	for i, t := range oneOf {
+		if t == nil {
+			test.Invalid(fmt.Sprintf("ExpectTypeOneOf: nil type specified (at index %d)", i))
+			return nil, false
+		}
+	}
+
+	m := types.OneOfMatcher{Expected: oneOf}
+	if opt.IsSet(opts, opt.ToNotMatch(true)) {
+		Expect(got, opts...).ShouldNot(m, opts...)
+		return nil, m.Matched(got) == nil
+	}
+
+	Expect(got, opts...).Should(m, opts...)
+
+	result := m.Matched(got)
+	return result, result != nil
+}
+
+// TypeOf returns the reflect.Type of T.  Unlike reflect.TypeOf, T may be
+// an interface type.
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// BeAssignableTo returns a matcher that checks that a value is assignable
+// to a type T.  If T is an interface, this tests that the value implements
+// the interface, with any missing methods identified in the test failure
+// report.
+//
+// A nil value is not assignable to any type.
+//
+// # Compatible Methods and Subjects
+//
+//	Expect(any(subject)).To(...)       // i.e. where 'subject' is of type `any`
+//	Expect(subject).Should(...)        // for any 'subject'
+//
+// # Supported Options
+//
+//	opt.FailureReport(func)     // a function that returns a custom test
+//	                            // failure report if the test fails.
+//
+//	opt.OnFailure(string)       // a string to output as the failure
+//	                            // report if the test fails.
+func BeAssignableTo[T any]() types.AssignableMatcher[T] {
+	return types.AssignableMatcher[T]{}
+}
+
+// HaveKind returns a matcher that checks that a value is of a specified
+// reflect.Kind.  A nil value is of kind reflect.Invalid.
+//
+// # Compatible Methods and Subjects
+//
+//	Expect(any(subject)).To(...)       // i.e. where 'subject' is of type `any`
+//	Expect(subject).Should(...)        // for any 'subject'
+//
+// # Supported Options
+//
+//	opt.FailureReport(func)     // a function that returns a custom test
+//	                            // failure report if the test fails.
+//
+//	opt.OnFailure(string)       // a string to output as the failure
+//	                            // report if the test fails.
+func HaveKind(k reflect.Kind) types.KindMatcher {
+	return types.KindMatcher{Expected: k}
+}
@@ -1,10 +1,14 @@
 package test_test
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"reflect"
 	"testing"
 
 	. "github.com/blugnu/test"
+	"github.com/blugnu/test/opt"
 	"github.com/blugnu/test/test"
 )
 
@@ -59,6 +63,156 @@ func TestExpectType(t *testing.T) {
 	}...))
 }
 
+func TestExpectImplements(t *testing.T) {
+	With(t)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "value implements interface",
+			Act: func() {
+				result, ok := ExpectImplements[io.Reader](&bytes.Buffer{})
+				Expect(ok).To(BeTrue())
+				Expect(result).IsNotNil()
+			},
+		},
+		{Scenario: "value does not implement interface",
+			Act: func() {
+				result, ok := ExpectImplements[io.ReadCloser](&bytes.Buffer{})
+				Expect(ok).To(BeFalse())
+				Expect(result).IsNil()
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: io.ReadCloser",
+					"got     : *bytes.Buffer",
+					"missing : Close",
+				)
+			},
+		},
+		{Scenario: "nil value",
+			Act: func() {
+				_, ok := ExpectImplements[io.Reader](nil)
+				Expect(ok).To(BeFalse())
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: io.Reader",
+					"got     : nil",
+				)
+			},
+		},
+		{Scenario: "not an interface",
+			Act: func() {
+				ExpectImplements[int](1)
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid(
+					"ExpectImplements: int is not an interface (use ExpectType)",
+				)
+			},
+		},
+		{Scenario: "required",
+			Act: func() {
+				RequireImplements[io.Closer](&bytes.Buffer{})
+				Expect(false).To(BeTrue()) // should not be evaluated
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value assignable to: io.Closer",
+					"got     : *bytes.Buffer",
+					"missing : Close",
+				)
+			},
+		},
+	}...))
+}
+
+func TestExpectTypeOneOf(t *testing.T) {
+	With(t)
+
+	var (
+		matched reflect.Type
+		ok      bool
+	)
+
+	Run(HelperTests([]HelperScenario{
+		{Scenario: "value of a concrete type",
+			Act: func() {
+				result, ok := ExpectTypeOneOf("s", []reflect.Type{TypeOf[int](), TypeOf[string]()})
+				Expect(ok).To(BeTrue())
+				Expect(result).To(Equal(reflect.TypeOf("")))
+			},
+		},
+		{Scenario: "value implementing an interface",
+			Act: func() {
+				result, ok := ExpectTypeOneOf(&bytes.Buffer{}, []reflect.Type{TypeOf[int](), TypeOf[io.Writer]()})
+				Expect(ok).To(BeTrue())
+				Expect(result).To(Equal(TypeOf[io.Writer]()))
+			},
+		},
+		{Scenario: "value of none of the types",
+			Act: func() {
+				result, ok := ExpectTypeOneOf(1.5, []reflect.Type{TypeOf[int](), TypeOf[string](), TypeOf[io.Reader]()})
+				Expect(ok).To(BeFalse())
+				Expect(result).IsNil()
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value of type one of: int, string, io.Reader",
+					"got     : float64",
+				)
+			},
+		},
+		{Scenario: "value of none of the types (ToNotMatch)",
+			Act: func() {
+				result, ok := ExpectTypeOneOf(1.5, []reflect.Type{TypeOf[int](), TypeOf[string]()}, opt.ToNotMatch(true))
+				Expect(ok).To(BeTrue())
+				Expect(result).IsNil()
+			},
+		},
+		{Scenario: "value of one of the types (ToNotMatch)",
+			Act: func() {
+				matched, ok = ExpectTypeOneOf("s", []reflect.Type{TypeOf[int](), TypeOf[string]()}, opt.ToNotMatch(true))
+			},
+			Assert: func(result *R) {
+				result.Expect(
+					"expected: value of type other than: int, string",
+					"got     : string",
+				)
+				Expect(ok).To(BeFalse())
+				Expect(matched).IsNil()
+			},
+		},
+		{Scenario: "value of none of the types (custom failure report)",
+			Act: func() {
+				ExpectTypeOneOf(1.5, []reflect.Type{TypeOf[int]()}, opt.OnFailure("not an int"))
+			},
+			Assert: func(result *R) {
+				result.Expect("not an int")
+			},
+		},
+		{Scenario: "no types",
+			Act: func() {
+				ExpectTypeOneOf(1, nil)
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid(
+					"ExpectTypeOneOf: no types specified",
+				)
+			},
+		},
+		{Scenario: "nil type",
+			Act: func() {
+				ExpectTypeOneOf(1, []reflect.Type{TypeOf[int](), nil})
+			},
+			Assert: func(result *R) {
+				result.ExpectInvalid(
+					"ExpectTypeOneOf: nil type specified (at index 1)",
+				)
+			},
+		},
+	}...))
+}
+
 func ExampleExpectType() {
 	test.Example()
 